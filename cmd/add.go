@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"tt/internal/store"
+	"tt/internal/timeparse"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	addStart    string
+	addEnd      string
+	addDuration string
+	addDate     string
+	addProject  string
+	addTags     []string
+)
+
+var addCmd = &cobra.Command{
+	Use:   "add [task | -]",
+	Short: "Add a finished session that was never timed",
+	Long: `Add a finished session to the logs.
+
+Pass "-" instead of a task to read one session per line from stdin:
+
+  start | end | task [| project [| tag,tag]]
+
+The end may also be a duration from the start, such as 45m or +1h. Blank
+lines and lines starting with # are skipped. Clock-only times use --date
+(default today), and --project/--tag apply to lines that do not set their
+own.`,
+	Example: `  tt add "client call" --start "2:00 PM" --end "2:45 PM"
+  tt add "code review" --duration 1h30m --date 2026-02-16
+  tt add "research" --start 09:00 --duration 2h --project acme/api
+  tt add - --date 2026-02-16 < notes.txt`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: startCmd.ValidArgsFunction,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+		day := now
+		if addDate != "" {
+			parsed, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(addDate), time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid --date value. use YYYY-MM-DD")
+			}
+			day = parsed
+		}
+
+		project, err := store.ParseProjectRef(addProject)
+		if err != nil {
+			return err
+		}
+		tags, err := store.NormalizeTags(addTags)
+		if err != nil {
+			return err
+		}
+
+		var entries []store.NewTaskLog
+		if args[0] == "-" {
+			if addStart != "" || addEnd != "" || addDuration != "" {
+				return fmt.Errorf("--start, --end, and --duration cannot be used when reading from stdin")
+			}
+			entries, err = readAddEntries(cmd.InOrStdin(), day, project, tags)
+			if err != nil {
+				return err
+			}
+			if len(entries) == 0 {
+				if machineOutput() {
+					return renderRecords([]store.TaskLogEntry{})
+				}
+				printEmpty("No entries read from stdin.")
+				return nil
+			}
+		} else {
+			task := strings.TrimSpace(args[0])
+			start, end, err := addSessionBounds(now, day, addDate != "")
+			if err != nil {
+				return err
+			}
+			entries = []store.NewTaskLog{{
+				TaskName:  task,
+				StartTime: start,
+				EndTime:   end,
+				Project:   project,
+				Tags:      tags,
+			}}
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		added, err := st.AddTaskLogs(entries)
+		if err != nil {
+			if errors.Is(err, store.ErrInvalidTimeRange) || errors.Is(err, store.ErrEmptyTaskName) {
+				return err
+			}
+			return fmt.Errorf("could not add logs: %w", err)
+		}
+		if machineOutput() {
+			return renderRecords(added)
+		}
+
+		if len(added) == 1 {
+			entry := added[0]
+			printSuccess("Added log %s (%s)", uiID(entry.ID), entry.TaskName)
+			if entry.ProjectName != "" {
+				printField("project", projectLabel(entry.ProjectName, entry.ClientName))
+			}
+			if len(entry.Tags) > 0 {
+				printField("tags", tagsLabel(entry.Tags))
+			}
+			printField("start", formatDateTime(entry.StartTime))
+			printField("end", formatDateTime(entry.EndTime))
+			printField("total", formatDuration(time.Duration(entry.DurationSeconds)*time.Second))
+			return nil
+		}
+
+		var total time.Duration
+		for _, entry := range added {
+			total += time.Duration(entry.DurationSeconds) * time.Second
+		}
+		printSuccess("Added %d logs", len(added))
+		printField("total", formatDuration(total))
+		for _, entry := range added {
+			fmt.Printf("  %s %s %s\n", uiID(entry.ID), formatDateTime(entry.StartTime), entry.TaskName)
+		}
+		return nil
+	},
+}
+
+// addSessionBounds works out start and end from --start, --end and
+// --duration. A bare --duration ends now, or starts at 9:00 AM on --date.
+func addSessionBounds(now time.Time, day time.Time, dateSet bool) (time.Time, time.Time, error) {
+	var (
+		start, end time.Time
+		duration   time.Duration
+		err        error
+	)
+
+	given := 0
+	if addStart != "" {
+		given++
+		if start, err = parseDateTimeValueOn(addStart, "--start", day); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if addEnd != "" {
+		given++
+		if end, err = parseDateTimeValueOn(addEnd, "--end", day); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if addDuration != "" {
+		given++
+		duration, err = timeparse.ParseDuration(addDuration)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --duration value: %w", err)
+		}
+		if duration <= 0 {
+			return time.Time{}, time.Time{}, fmt.Errorf("--duration must be greater than zero")
+		}
+	}
+	if given > 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("use at most two of --start, --end, and --duration")
+	}
+
+	switch {
+	case addStart != "" && addEnd != "":
+	case addStart != "" && addDuration != "":
+		end = start.Add(duration)
+	case addEnd != "" && addDuration != "":
+		start = end.Add(-duration)
+	case addDuration != "" && dateSet:
+		start = time.Date(day.Year(), day.Month(), day.Day(), 9, 0, 0, 0, time.Local)
+		end = start.Add(duration)
+	case addDuration != "":
+		end = now
+		start = now.Add(-duration)
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("provide --start and --end, or --duration")
+	}
+
+	return start, end, nil
+}
+
+func readAddEntries(r io.Reader, day time.Time, project store.ProjectRef, tags []string) ([]store.NewTaskLog, error) {
+	var entries []store.NewTaskLog
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		entry, err := parseAddLine(line, day, project, tags)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func parseAddLine(line string, day time.Time, project store.ProjectRef, tags []string) (store.NewTaskLog, error) {
+	fields := strings.Split(line, "|")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	if len(fields) < 3 || len(fields) > 5 {
+		return store.NewTaskLog{}, fmt.Errorf("expected 'start | end | task [| project [| tags]]'")
+	}
+
+	start, err := parseDateTimeValueOn(fields[0], "start", day)
+	if err != nil {
+		return store.NewTaskLog{}, err
+	}
+	end, err := parseAddLineEnd(fields[1], start, day)
+	if err != nil {
+		return store.NewTaskLog{}, err
+	}
+	if end.Before(start) {
+		return store.NewTaskLog{}, store.ErrInvalidTimeRange
+	}
+	if fields[2] == "" {
+		return store.NewTaskLog{}, store.ErrEmptyTaskName
+	}
+
+	if len(fields) >= 4 && fields[3] != "" {
+		project, err = store.ParseProjectRef(fields[3])
+		if err != nil {
+			return store.NewTaskLog{}, err
+		}
+	}
+	if len(fields) == 5 && fields[4] != "" {
+		tags, err = store.NormalizeTags(strings.Split(fields[4], ","))
+		if err != nil {
+			return store.NewTaskLog{}, err
+		}
+	}
+
+	return store.NewTaskLog{
+		TaskName:  fields[2],
+		StartTime: start,
+		EndTime:   end,
+		Project:   project,
+		Tags:      tags,
+	}, nil
+}
+
+// parseAddLineEnd reads the end column: a duration from the line's start,
+// optionally written with a leading "+", or a time on day. Offsets from now
+// such as "-30m" or "in 10m" are rejected, since they would ignore the start.
+func parseAddLineEnd(value string, start time.Time, day time.Time) (time.Time, error) {
+	if duration, err := timeparse.ParseDuration(strings.TrimPrefix(value, "+")); err == nil && duration > 0 {
+		return start.Add(duration), nil
+	}
+
+	lower := strings.ToLower(value)
+	if strings.HasPrefix(lower, "-") || strings.HasPrefix(lower, "+") || strings.HasPrefix(lower, "in ") ||
+		strings.HasSuffix(lower, " ago") || lower == "now" {
+		return time.Time{}, fmt.Errorf("invalid end %q. use a time, or a duration from the start like 45m or +1h", value)
+	}
+	end, err := parseDateTimeValueOn(value, "end", day)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid end %q. use a time, or a duration from the start like 45m or +1h", value)
+	}
+	return end, nil
+}
+
+func init() {
+	rootCmd.AddCommand(addCmd)
+	supportsRecords(addCmd)
+
+	addCmd.Flags().StringVar(&addStart, "start", "", "session start time")
+	addCmd.Flags().StringVar(&addEnd, "end", "", "session end time")
+	addCmd.Flags().StringVar(&addDuration, "duration", "", "session length (e.g. 1h30m)")
+	addCmd.Flags().StringVar(&addDate, "date", "", "day for clock-only times (YYYY-MM-DD, default today)")
+	addCmd.Flags().StringVar(&addProject, "project", "", "attach the session to a project (project or client/project)")
+	addCmd.Flags().StringSliceVar(&addTags, "tag", nil, "tag the session (repeatable)")
+
+	_ = addCmd.RegisterFlagCompletionFunc("project", completeProjects)
+	_ = addCmd.RegisterFlagCompletionFunc("tag", completeTags)
+}
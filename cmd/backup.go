@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup <file>",
+	Short: "Write all tracked data to a JSON backup file",
+	Long: `Write active tasks, logs, projects, tags, settings, and the trash to a
+versioned JSON document. Pass "-" to write to stdout. Load it again with
+tt restore.`,
+	Example: `  tt backup ~/tt-backup.json
+  tt backup - | gzip > tt-backup.json.gz`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		backup, err := st.Backup()
+		if err != nil {
+			return fmt.Errorf("could not read data for backup: %w", err)
+		}
+		data, err := json.MarshalIndent(backup, "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not encode backup: %w", err)
+		}
+		data = append(data, '\n')
+
+		if args[0] == "-" {
+			_, err := os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(args[0], data, 0600); err != nil {
+			return fmt.Errorf("could not write backup: %w", err)
+		}
+
+		printSuccess("Backed up to %s", args[0])
+		printField("logs", fmt.Sprintf("%d", len(backup.Logs)))
+		printField("active", fmt.Sprintf("%d", len(backup.ActiveTasks)))
+		printField("projects", fmt.Sprintf("%d", len(backup.Projects)))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+}
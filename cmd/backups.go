@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var backupsCmd = &cobra.Command{
+	Use:   "backups",
+	Short: "List and restore automatic database snapshots",
+	Long: `tt snapshots the database into ~/.tt/backups before deletes, imports,
+restores, and overlap fixes. The newest snapshots are kept; change how many
+with "tt config set backups.keep N" (0 turns snapshots off).`,
+}
+
+var backupsListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List automatic snapshots, newest first",
+	Example: `  tt backups list`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		snapshots, err := st.ListSnapshots()
+		if err != nil {
+			return fmt.Errorf("could not list snapshots: %w", err)
+		}
+		if machineOutput() {
+			return renderRecords(snapshots)
+		}
+		if len(snapshots) == 0 {
+			printEmpty("No snapshots yet.")
+			return nil
+		}
+
+		printSection("Snapshots")
+		for i, snapshot := range snapshots {
+			fmt.Printf("%d) %s\n", i+1, snapshot.Name)
+			printField("taken", formatDateTime(snapshot.CreatedAt))
+			printField("before", snapshot.Reason)
+			printField("size", formatSize(snapshot.Size))
+			if i < len(snapshots)-1 {
+				fmt.Println()
+			}
+		}
+		return nil
+	},
+}
+
+var backupsRestoreCmd = &cobra.Command{
+	Use:   "restore <name>",
+	Short: "Replace the database with a snapshot",
+	Long: `Replace the database with a snapshot from tt backups list. The current
+database is snapshotted first, so a restore can itself be rolled back.`,
+	Example: `  tt backups restore tt-20260216-093000.123-delete.db`,
+	Args:    cobra.ExactArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		snapshots, err := st.ListSnapshots()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		names := make([]string, 0, len(snapshots))
+		for _, snapshot := range snapshots {
+			names = append(names, snapshot.Name+"\t"+snapshot.Reason+", "+formatDateTime(snapshot.CreatedAt))
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		if err := st.RestoreSnapshot(args[0]); err != nil {
+			if errors.Is(err, store.ErrSnapshotNotFound) {
+				return fmt.Errorf("snapshot %q not found. see tt backups list", args[0])
+			}
+			return fmt.Errorf("could not restore snapshot: %w", err)
+		}
+
+		printSuccess("Restored snapshot %s", args[0])
+		printInfo("The previous database was snapshotted first; see %q.", "tt backups list")
+		return nil
+	},
+}
+
+func formatSize(bytes int64) string {
+	switch {
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1<<20))
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", bytes)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(backupsCmd)
+	backupsCmd.AddCommand(backupsListCmd)
+	backupsCmd.AddCommand(backupsRestoreCmd)
+	supportsRecords(backupsListCmd)
+}
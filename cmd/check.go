@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var checkFix string
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Find overlapping, empty, and overnight sessions",
+	Long: `Check the logs for overlapping sessions, zero-length sessions, and sessions
+that span midnight.
+
+Overlaps can be resolved with --fix:
+  trim-earlier  end the earlier session when the later one starts
+  trim-later    start the later session when the earlier one ends
+  split         move both to the middle of the overlap
+
+A session that lies wholly inside another is left alone and listed, since
+none of these can fix it without losing worked time.
+
+With --output, prints one record per issue left after any fix.`,
+	Example: `  tt check
+  tt check --fix trim-earlier
+  tt check --fix split`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var strategy store.OverlapStrategy
+		if cmd.Flags().Changed("fix") {
+			parsed, err := store.ParseOverlapStrategy(checkFix)
+			if err != nil {
+				return err
+			}
+			strategy = parsed
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		if strategy != "" {
+			fixed, skipped, err := st.FixOverlaps(strategy)
+			if err != nil {
+				return fmt.Errorf("could not fix overlaps: %w", err)
+			}
+			if !machineOutput() {
+				printFixResult(strategy, fixed, skipped)
+			}
+		}
+
+		issues, err := st.FindLogIssues()
+		if err != nil {
+			return fmt.Errorf("could not check logs: %w", err)
+		}
+		if machineOutput() {
+			return renderRecords(logIssueRecords(issues))
+		}
+		if len(issues) == 0 {
+			printSuccess("No issues found")
+			return nil
+		}
+
+		counts := map[store.LogIssueKind]int{}
+		for _, issue := range issues {
+			counts[issue.Kind]++
+		}
+
+		printSection("Log Check")
+		printField("overlap", fmt.Sprintf("%d", counts[store.IssueOverlap]))
+		printField("empty", fmt.Sprintf("%d", counts[store.IssueZeroLength]))
+		printField("midnight", fmt.Sprintf("%d", counts[store.IssueSpansMidnight]))
+		fmt.Println()
+
+		for i, issue := range issues {
+			switch issue.Kind {
+			case store.IssueOverlap:
+				fmt.Printf("%d) %s by %s\n", i+1, uiWarn("overlap"), formatDuration(issue.Overlap))
+				printField("earlier", formatSessionRef(issue.Log))
+				printField("later", formatSessionRef(issue.Other))
+			case store.IssueZeroLength:
+				fmt.Printf("%d) %s\n", i+1, uiWarn("zero-length session"))
+				printField("log", formatSessionRef(issue.Log))
+			case store.IssueSpansMidnight:
+				fmt.Printf("%d) %s\n", i+1, uiWarn("spans midnight"))
+				printField("log", formatSessionRef(issue.Log))
+			}
+			if i < len(issues)-1 {
+				fmt.Println()
+			}
+		}
+
+		if counts[store.IssueOverlap] > 0 && strategy == "" {
+			fmt.Println()
+			printInfo("Use %q to resolve overlaps.", "tt check --fix trim-earlier|trim-later|split")
+		}
+		return nil
+	},
+}
+
+func printFixResult(strategy store.OverlapStrategy, fixed []store.LogIssue, skipped []store.LogIssue) {
+	if len(fixed) == 0 && len(skipped) == 0 {
+		printEmpty("No overlapping sessions to fix.")
+	}
+	if len(fixed) > 0 {
+		printSuccess("Fixed %d overlap(s) with %s", len(fixed), strategy)
+		for _, issue := range fixed {
+			printField("earlier", formatSessionRef(issue.Log))
+			printField("later", formatSessionRef(issue.Other))
+		}
+		fmt.Println()
+	}
+	if len(skipped) > 0 {
+		printEmpty("Skipped %d overlap(s) where one session lies inside another", len(skipped))
+		for _, issue := range skipped {
+			printField("outer", formatSessionRef(issue.Log))
+			printField("inner", formatSessionRef(issue.Other))
+		}
+		printInfo("Fix these by hand with %q or %q.", "tt split", "tt update")
+		fmt.Println()
+	}
+}
+
+// logIssueRecord is a LogIssue flattened for --output. The other_* fields
+// are only set for overlaps.
+type logIssueRecord struct {
+	Kind           string     `json:"kind"`
+	LogID          string     `json:"log_id"`
+	Task           string     `json:"task"`
+	StartTime      time.Time  `json:"start_time"`
+	EndTime        time.Time  `json:"end_time"`
+	OtherID        string     `json:"other_id"`
+	OtherTask      string     `json:"other_task"`
+	OtherStartTime *time.Time `json:"other_start_time"`
+	OtherEndTime   *time.Time `json:"other_end_time"`
+	OverlapSeconds int        `json:"overlap_seconds"`
+}
+
+func logIssueRecords(issues []store.LogIssue) []logIssueRecord {
+	records := make([]logIssueRecord, 0, len(issues))
+	for _, issue := range issues {
+		record := logIssueRecord{
+			Kind:      string(issue.Kind),
+			LogID:     issue.Log.ID,
+			Task:      issue.Log.TaskName,
+			StartTime: issue.Log.StartTime,
+			EndTime:   issue.Log.EndTime,
+		}
+		if issue.Kind == store.IssueOverlap {
+			record.OtherID = issue.Other.ID
+			record.OtherTask = issue.Other.TaskName
+			record.OtherStartTime = &issue.Other.StartTime
+			record.OtherEndTime = &issue.Other.EndTime
+			record.OverlapSeconds = int(issue.Overlap.Seconds())
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func formatSessionRef(entry store.TaskLogEntry) string {
+	return fmt.Sprintf(
+		"%s %s %s",
+		uiID(entry.ID),
+		entry.TaskName,
+		uiMuted("("+formatDateTime(entry.StartTime)+" - "+formatDateTime(entry.EndTime)+")"),
+	)
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+	supportsRecords(checkCmd)
+
+	checkCmd.Flags().StringVar(&checkFix, "fix", "", "resolve overlaps: trim-earlier, trim-later, or split")
+	_ = checkCmd.RegisterFlagCompletionFunc("fix", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{
+			string(store.TrimEarlier),
+			string(store.TrimLater),
+			string(store.SplitOverlap),
+		}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
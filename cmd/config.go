@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show and change tt settings",
+	Example: `  tt config list
+  tt config set start.exclusive true
+  tt config unset start.exclusive`,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List settings and their values",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		settings, err := st.GetSettings()
+		if err != nil {
+			return fmt.Errorf("could not read settings: %w", err)
+		}
+		if machineOutput() {
+			return renderRecords(settings)
+		}
+
+		printSection("Settings")
+		for i, setting := range settings {
+			value := setting.Value
+			if !setting.IsSet {
+				value += " " + uiMuted("(default)")
+			}
+			fmt.Println(setting.Key)
+			printField("value", value)
+			printField("about", setting.Description)
+			if i < len(settings)-1 {
+				fmt.Println()
+			}
+		}
+		return nil
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:               "get [key]",
+	Short:             "Print the value of a setting",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSettingKeys,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		setting, err := st.GetSetting(args[0])
+		if err != nil {
+			return settingError(args[0], err)
+		}
+		fmt.Println(setting.Value)
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:               "set [key] [value]",
+	Short:             "Change a setting",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeSettingKeys,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		if err := st.SetSetting(args[0], args[1]); err != nil {
+			return settingError(args[0], err)
+		}
+		printSuccess("Set %s", args[0])
+		printField("value", args[1])
+		return nil
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:               "unset [key]",
+	Short:             "Reset a setting to its default",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSettingKeys,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		if err := st.UnsetSetting(args[0]); err != nil {
+			return settingError(args[0], err)
+		}
+		printSuccess("Reset %s to its default", args[0])
+		return nil
+	},
+}
+
+func settingError(key string, err error) error {
+	if errors.Is(err, store.ErrUnknownSetting) {
+		return fmt.Errorf("unknown setting %q. see %q", key, "tt config list")
+	}
+	return err
+}
+
+func completeSettingKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	st, err := store.Open()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	settings, err := st.GetSettings()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	keys := make([]string, 0, len(settings))
+	for _, setting := range settings {
+		keys = append(keys, setting.Key+"\t"+setting.Description)
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	supportsRecords(configListCmd)
+}
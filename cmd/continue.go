@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	continueAt     atFlags
+	continueFuture bool
+)
+
+var continueCmd = &cobra.Command{
+	Use:   "continue [log-id]",
+	Short: "Restart the most recent task (or the task of a given log)",
+	Example: `  tt continue
+  tt continue a1b2c3d4
+  tt continue --ago 5m`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeLogIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+		startTime, err := continueAt.resolve(now)
+		if err != nil {
+			return err
+		}
+		if startTime.After(now) && !continueFuture {
+			return fmt.Errorf("start time %s is in the future. pass --future to allow it", formatDateTime(startTime))
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		var entry store.TaskLogEntry
+		if len(args) == 1 {
+			id := args[0]
+			if !store.IsValidLogID(id) {
+				return fmt.Errorf("log-id must be an 8-character alphanumeric value")
+			}
+			entry, err = st.GetTaskLog(id)
+			if err != nil {
+				if errors.Is(err, store.ErrLogNotFound) {
+					return fmt.Errorf("log with id %s not found", id)
+				}
+				return fmt.Errorf("could not get log %s: %w", id, err)
+			}
+		} else {
+			recent, err := st.GetRecentTaskLogs(1)
+			if err != nil {
+				return fmt.Errorf("could not get recent logs: %w", err)
+			}
+			if len(recent) == 0 {
+				if machineOutput() {
+					return renderRecords([]store.ActiveTask{})
+				}
+				printEmpty("No logs to continue yet.")
+				return nil
+			}
+			entry = recent[0]
+		}
+
+		opts := store.StartOptions{
+			Project:   store.ProjectRef{Client: entry.ClientName, Name: entry.ProjectName},
+			Tags:      entry.Tags,
+			StartTime: startTime,
+		}
+
+		exclusive, err := st.BoolSetting(store.SettingStartExclusive)
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", store.SettingStartExclusive, err)
+		}
+		if exclusive {
+			return switchTask(st, entry.TaskName, opts)
+		}
+
+		if err := st.StartTask(entry.TaskName, opts); err != nil {
+			if errors.Is(err, store.ErrTaskAlreadyActive) {
+				return fmt.Errorf("task %q is already active", entry.TaskName)
+			}
+			return fmt.Errorf("could not start task: %w", err)
+		}
+
+		return printStarted(st, entry.TaskName, opts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(continueCmd)
+	supportsRecords(continueCmd)
+
+	continueAt.register(continueCmd, "start")
+	continueCmd.Flags().BoolVar(&continueFuture, "future", false, "allow a start time in the future")
+}
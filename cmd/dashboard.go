@@ -10,11 +10,15 @@ import (
 )
 
 var (
-	dashboardToday bool
-	dashboardWeek  bool
-	dashboardMonth bool
-	dashboardAll   bool
-	dashboardSince string
+	dashboardToday  bool
+	dashboardWeek   bool
+	dashboardMonth  bool
+	dashboardAll    bool
+	dashboardSince  string
+	dashboardBy     string
+	dashboardChart  string
+	dashboardRange  rangeFlags
+	dashboardFilter logFilterFlags
 )
 
 var dashboardCmd = &cobra.Command{
@@ -24,7 +28,15 @@ var dashboardCmd = &cobra.Command{
   tt dash --week
   tt dash --month
   tt dash --all
-  tt dash --week --since 2026-02-01`,
+  tt dash --week --since 2026-02-01
+  tt dash --range last-month
+  tt dash --from 2026-01-01 --to 2026-03-31 --by client
+  tt dash --month --by client
+  tt dash --week --client acme --by project
+  tt dash --month --by tag
+  tt dash --tag billable,oncall
+  tt dash --week --chart horizontal
+  tt dash --month --by project --chart vertical`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		_ = args
 
@@ -41,12 +53,22 @@ var dashboardCmd = &cobra.Command{
 		if dashboardAll {
 			periodCount++
 		}
+		if dashboardRange.isSet() {
+			periodCount++
+		}
 		if periodCount > 1 {
-			return fmt.Errorf("use only one of --today, --week, --month, or --all")
+			return fmt.Errorf("use only one of --today, --week, --month, --all, or --from/--to/--range")
+		}
+		by, err := store.ParseGroupBy(dashboardBy)
+		if err != nil {
+			return err
+		}
+		if err := validateDashboardChart(dashboardChart); err != nil {
+			return err
 		}
 
 		now := time.Now()
-		since, periodLabel, err := dashboardSinceStart(now, cmd.Flags().Changed("since"))
+		period, periodLabel, err := dashboardPeriod(now, cmd.Flags().Changed("since"))
 		if err != nil {
 			return err
 		}
@@ -56,15 +78,18 @@ var dashboardCmd = &cobra.Command{
 			return err
 		}
 
-		var sincePtr *time.Time
-		if !since.IsZero() {
-			sincePtr = &since
+		filter, err := dashboardFilter.filter(period)
+		if err != nil {
+			return err
 		}
 
-		rows, totalSeconds, err := st.GetTaskDurationSummary(sincePtr)
+		rows, totalSeconds, err := st.GetTaskDurationSummary(filter, by)
 		if err != nil {
 			return fmt.Errorf("could not build dashboard: %w", err)
 		}
+		if machineOutput() {
+			return renderRecords(rows)
+		}
 		if len(rows) == 0 {
 			printEmpty("No logs found for %s.", periodLabel)
 			return nil
@@ -72,27 +97,47 @@ var dashboardCmd = &cobra.Command{
 
 		printSection("Dashboard")
 		printField("period", periodLabel)
-		if sincePtr != nil {
-			printField("since", since.Local().Format("2006-01-02"))
+		if !filter.Project.IsZero() {
+			printField("project", filter.Project.String())
+		}
+		if filter.Client != "" {
+			printField("client", filter.Client)
+		}
+		if len(dashboardFilter.tags) > 0 {
+			printField("tags", tagsLabel(dashboardFilter.tags))
+		}
+		if period.From != nil {
+			printField("since", period.From.Local().Format("2006-01-02"))
+		}
+		if period.To != nil && dashboardRange.isSet() {
+			printField("until", period.To.Local().Format("2006-01-02 15:04"))
 		}
 		printField("total", formatDuration(time.Duration(totalSeconds)*time.Second))
-		shareBaseSeconds, shareBaseLabel := dashboardShareBaseSeconds(now, since, cmd.Flags().Changed("since"), periodLabel, totalSeconds)
+		shareBaseSeconds, shareBaseLabel := dashboardShareBaseSeconds(now, period, cmd.Flags().Changed("since"), periodLabel, totalSeconds)
 		printField("base", shareBaseLabel)
 		fmt.Println()
 
-		// Histogram is intentionally disabled for now.
-		// chartLines, legendLines := dashboardVerticalHistogram(rows, shareBaseSeconds)
-		// for _, line := range chartLines {
-		// 	fmt.Println(line)
-		// }
-		// for _, line := range legendLines {
-		// 	fmt.Println(line)
-		// }
-		// fmt.Println()
+		width := terminalWidth()
+		switch dashboardChart {
+		case dashboardChartVertical:
+			chartLines, legendLines := dashboardVerticalHistogram(rows, by, shareBaseSeconds, width)
+			for _, line := range chartLines {
+				fmt.Println(line)
+			}
+			for _, line := range legendLines {
+				fmt.Println(line)
+			}
+			fmt.Println()
+		case dashboardChartHorizontal:
+			for _, line := range dashboardHorizontalChart(rows, by, shareBaseSeconds, width) {
+				fmt.Println(line)
+			}
+			fmt.Println()
+		}
 
 		for i, row := range rows {
 			pct := (float64(row.DurationSeconds) / float64(shareBaseSeconds)) * 100
-			fmt.Printf("%d) %s\n", i+1, row.TaskName)
+			fmt.Printf("%d) %s\n", i+1, groupLabel(by, row.TaskName, row.ProjectName, row.ClientName, row.Tag))
 			printField("time", formatDuration(time.Duration(row.DurationSeconds)*time.Second))
 			printField("share", fmt.Sprintf("%.1f%%", pct))
 			if i < len(rows)-1 {
@@ -104,11 +149,21 @@ var dashboardCmd = &cobra.Command{
 	},
 }
 
-func dashboardSinceStart(now time.Time, sinceFlagSet bool) (time.Time, string, error) {
+// dashboardPeriod returns the range the dashboard covers and its label.
+func dashboardPeriod(now time.Time, sinceFlagSet bool) (store.TimeRange, string, error) {
 	var periodStart time.Time
 	periodLabel := "today"
 
 	switch {
+	case dashboardRange.isSet():
+		period, err := dashboardRange.resolve(now)
+		if err != nil {
+			return store.TimeRange{}, "", err
+		}
+		if sinceFlagSet {
+			return store.TimeRange{}, "", fmt.Errorf("--since cannot be combined with --from/--to/--range")
+		}
+		return period, dashboardRange.label(), nil
 	case dashboardAll:
 		periodLabel = "all time"
 	case dashboardWeek:
@@ -125,19 +180,19 @@ func dashboardSinceStart(now time.Time, sinceFlagSet bool) (time.Time, string, e
 	if sinceFlagSet {
 		value := strings.TrimSpace(dashboardSince)
 		if value == "" {
-			return time.Time{}, "", fmt.Errorf("--since cannot be empty")
+			return store.TimeRange{}, "", fmt.Errorf("--since cannot be empty")
 		}
-		parsed, err := time.ParseInLocation("2006-01-02", value, now.Location())
+		bound, err := parseRangeBound(value, "--since", now)
 		if err != nil {
-			return time.Time{}, "", fmt.Errorf("invalid --since value. use YYYY-MM-DD")
+			return store.TimeRange{}, "", err
 		}
 
-		if periodStart.IsZero() || parsed.After(periodStart) {
-			periodStart = parsed
+		if periodStart.IsZero() || bound.From.After(periodStart) {
+			periodStart = *bound.From
 		}
 	}
 
-	return periodStart, periodLabel, nil
+	return store.NewTimeRange(periodStart, time.Time{}), periodLabel, nil
 }
 
 func startOfCurrentWeek(now time.Time) time.Time {
@@ -147,7 +202,25 @@ func startOfCurrentWeek(now time.Time) time.Time {
 	return time.Date(base.Year(), base.Month(), base.Day(), 0, 0, 0, 0, base.Location())
 }
 
-func dashboardShareBaseSeconds(now time.Time, since time.Time, sinceFlagSet bool, periodLabel string, totalSeconds int) (int, string) {
+func dashboardShareBaseSeconds(now time.Time, period store.TimeRange, sinceFlagSet bool, periodLabel string, totalSeconds int) (int, string) {
+	if dashboardRange.isSet() {
+		switch {
+		case period.From != nil && period.To != nil:
+			hours := int(period.To.Sub(*period.From).Hours())
+			return int(period.To.Sub(*period.From).Seconds()), fmt.Sprintf("%dh (%d days)", hours, (hours+23)/24)
+		case period.From != nil:
+			seconds := int(now.Sub(*period.From).Seconds())
+			if seconds < 1 {
+				seconds = 1
+			}
+			return seconds, fmt.Sprintf("since %s", period.From.Local().Format("2006-01-02"))
+		}
+		if totalSeconds > 0 {
+			return totalSeconds, "tracked total"
+		}
+		return 1, "tracked total"
+	}
+
 	switch periodLabel {
 	case "today":
 		return 24 * 60 * 60, "24h"
@@ -160,12 +233,12 @@ func dashboardShareBaseSeconds(now time.Time, since time.Time, sinceFlagSet bool
 		baseSeconds := daysInMonth * 24 * 60 * 60
 		return baseSeconds, fmt.Sprintf("%dh (%d days)", daysInMonth*24, daysInMonth)
 	case "all time":
-		if sinceFlagSet && !since.IsZero() {
-			seconds := int(time.Since(since).Seconds())
+		if sinceFlagSet && period.From != nil {
+			seconds := int(time.Since(*period.From).Seconds())
 			if seconds < 1 {
 				seconds = 1
 			}
-			return seconds, fmt.Sprintf("since %s", since.Local().Format("2006-01-02"))
+			return seconds, fmt.Sprintf("since %s", period.From.Local().Format("2006-01-02"))
 		}
 		if totalSeconds > 0 {
 			return totalSeconds, "tracked total"
@@ -176,34 +249,65 @@ func dashboardShareBaseSeconds(now time.Time, since time.Time, sinceFlagSet bool
 	}
 }
 
-func dashboardVerticalHistogram(rows []store.TaskDurationSummary, shareBaseSeconds int) ([]string, []string) {
-	const (
-		maxBars = 12
-		height  = 10
-	)
+const (
+	dashboardChartNone       = "none"
+	dashboardChartVertical   = "vertical"
+	dashboardChartHorizontal = "horizontal"
+)
 
-	type bar struct {
-		name    string
-		seconds int
-		pct     float64
+var dashboardChartModes = []string{dashboardChartNone, dashboardChartVertical, dashboardChartHorizontal}
+
+func validateDashboardChart(mode string) error {
+	for _, known := range dashboardChartModes {
+		if mode == known {
+			return nil
+		}
 	}
+	return fmt.Errorf("invalid --chart value %q. use %s", mode, strings.Join(dashboardChartModes, ", "))
+}
 
-	bars := make([]bar, 0, len(rows))
+type dashboardBar struct {
+	name    string
+	seconds int
+	pct     float64
+}
+
+// dashboardBars turns summary rows into chart bars. When there are more
+// than maxBars rows, the smallest are folded into a final "others" bar.
+func dashboardBars(rows []store.TaskDurationSummary, by store.GroupBy, shareBaseSeconds int, maxBars int) []dashboardBar {
+	bars := make([]dashboardBar, 0, len(rows))
 	for _, row := range rows {
 		pct := (float64(row.DurationSeconds) / float64(shareBaseSeconds)) * 100
-		bars = append(bars, bar{name: row.TaskName, seconds: row.DurationSeconds, pct: pct})
+		bars = append(bars, dashboardBar{
+			name:    groupLabel(by, row.TaskName, row.ProjectName, row.ClientName, row.Tag),
+			seconds: row.DurationSeconds,
+			pct:     pct,
+		})
 	}
 
-	visible := bars
-	if len(bars) > maxBars {
-		visible = append([]bar{}, bars[:maxBars-1]...)
-		others := bar{name: "others"}
-		for _, b := range bars[maxBars-1:] {
-			others.seconds += b.seconds
-			others.pct += b.pct
-		}
-		visible = append(visible, others)
+	if maxBars < 2 {
+		maxBars = 2
+	}
+	if len(bars) <= maxBars {
+		return bars
+	}
+	visible := append([]dashboardBar{}, bars[:maxBars-1]...)
+	others := dashboardBar{name: uiMuted(fmt.Sprintf("others (%d)", len(bars)-maxBars+1))}
+	for _, b := range bars[maxBars-1:] {
+		others.seconds += b.seconds
+		others.pct += b.pct
 	}
+	return append(visible, others)
+}
+
+func dashboardVerticalHistogram(rows []store.TaskDurationSummary, by store.GroupBy, shareBaseSeconds int, width int) ([]string, []string) {
+	const (
+		maxBars = 12
+		height  = 10
+	)
+
+	// Each bar takes three columns after the six-column axis.
+	visible := dashboardBars(rows, by, shareBaseSeconds, min(maxBars, (width-6)/3))
 
 	chart := []string{"Histogram (% of base):"}
 	for level := height; level >= 1; level-- {
@@ -228,27 +332,85 @@ func dashboardVerticalHistogram(rows []store.TaskDurationSummary, shareBaseSecon
 
 	legend := []string{"Legend:"}
 	for i, b := range visible {
-		legend = append(
-			legend,
-			fmt.Sprintf(
-				"  %2d) %s - %.1f%% (%s)",
-				i+1,
-				b.name,
-				b.pct,
-				formatDuration(time.Duration(b.seconds)*time.Second),
-			),
-		)
+		detail := fmt.Sprintf(" - %.1f%% (%s)", b.pct, formatDuration(time.Duration(b.seconds)*time.Second))
+		name := truncateVisible(b.name, max(8, width-6-len(detail)))
+		legend = append(legend, fmt.Sprintf("  %2d) %s%s", i+1, name, detail))
 	}
 
 	return chart, legend
 }
 
+// dashboardHorizontalChart draws one bar per row, scaled so the largest
+// share fills the space left after the label and the numbers.
+func dashboardHorizontalChart(rows []store.TaskDurationSummary, by store.GroupBy, shareBaseSeconds int, width int) []string {
+	const (
+		maxBars     = 15
+		minBarWidth = 10
+	)
+
+	visible := dashboardBars(rows, by, shareBaseSeconds, maxBars)
+
+	labelWidth := 0
+	largest := 0.0
+	for _, b := range visible {
+		labelWidth = max(labelWidth, visibleWidth(b.name))
+		largest = max(largest, b.pct)
+	}
+
+	// "NN) " before the label, " 100.0%  123h 45m" after the bar.
+	const prefixWidth, pctWidth, durationWidth = 4, 7, 9
+	showDuration := true
+	barWidth := width - prefixWidth - labelWidth - 1 - pctWidth - durationWidth
+	if barWidth < minBarWidth {
+		labelWidth = max(8, labelWidth-(minBarWidth-barWidth))
+		barWidth = width - prefixWidth - labelWidth - 1 - pctWidth - durationWidth
+	}
+	if barWidth < minBarWidth {
+		showDuration = false
+		barWidth = max(minBarWidth/2, width-prefixWidth-labelWidth-1-pctWidth)
+	}
+
+	lines := []string{"Chart (% of base):"}
+	for i, b := range visible {
+		filled := 0
+		if largest > 0 {
+			filled = int(b.pct/largest*float64(barWidth) + 0.5)
+		}
+		if filled == 0 && b.seconds > 0 {
+			filled = 1
+		}
+		line := fmt.Sprintf(
+			"%2d) %s %s%s%s",
+			i+1,
+			padRight(truncateVisible(b.name, labelWidth), labelWidth),
+			uiAccent(strings.Repeat("█", filled)),
+			strings.Repeat(" ", barWidth-filled),
+			padLeft(fmt.Sprintf("%.1f%%", b.pct), pctWidth),
+		)
+		if showDuration {
+			line += padLeft(formatDuration(time.Duration(b.seconds)*time.Second), durationWidth)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
 func init() {
 	rootCmd.AddCommand(dashboardCmd)
+	supportsRecords(dashboardCmd)
 
 	dashboardCmd.Flags().BoolVar(&dashboardToday, "today", false, "show dashboard for today (default)")
 	dashboardCmd.Flags().BoolVar(&dashboardWeek, "week", false, "show dashboard for the current week")
 	dashboardCmd.Flags().BoolVar(&dashboardMonth, "month", false, "show dashboard for the current month")
 	dashboardCmd.Flags().BoolVar(&dashboardAll, "all", false, "show dashboard for all-time logs")
-	dashboardCmd.Flags().StringVar(&dashboardSince, "since", "", "show data since date (YYYY-MM-DD)")
+	dashboardCmd.Flags().StringVar(&dashboardSince, "since", "", "show data since a date, time ('last monday 09:00', '2h ago'), or range name")
+	dashboardCmd.Flags().StringVar(&dashboardBy, "by", "task", "group time by task, project, client, or tag")
+	dashboardCmd.Flags().StringVar(&dashboardChart, "chart", dashboardChartNone, "draw a chart of each share: vertical, horizontal, or none")
+	dashboardRange.register(dashboardCmd, "show")
+	dashboardFilter.register(dashboardCmd, "show")
+
+	_ = dashboardCmd.RegisterFlagCompletionFunc("by", completeGroupBy)
+	_ = dashboardCmd.RegisterFlagCompletionFunc("chart", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return dashboardChartModes, cobra.ShellCompDirectiveNoFileComp
+	})
 }
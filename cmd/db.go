@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var dbMigrateStatus bool
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Inspect and maintain the tt database",
+}
+
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply pending schema migrations",
+	Example: `  tt db migrate
+  tt db migrate --status`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := store.OpenUnmigrated()
+		if err != nil {
+			return err
+		}
+
+		if dbMigrateStatus {
+			statuses, err := st.MigrationStatus()
+			if err != nil {
+				return fmt.Errorf("could not read schema version: %w", err)
+			}
+
+			printSection("Schema Migrations")
+			printField("binary", fmt.Sprintf("%d", store.SchemaVersion()))
+			fmt.Println()
+			for _, status := range statuses {
+				state := uiWarn("pending")
+				switch {
+				case status.Unknown:
+					state = uiWarn("unknown (newer than this binary)")
+				case status.AppliedAt != nil:
+					state = uiGood("applied " + formatDateTime(*status.AppliedAt))
+				}
+				fmt.Printf("%d) %s\n", status.Version, status.Name)
+				printField("state", state)
+			}
+			return nil
+		}
+
+		applied, err := st.Migrate()
+		if err != nil {
+			return fmt.Errorf("could not migrate database: %w", err)
+		}
+		if len(applied) == 0 {
+			printEmpty("Database is up to date (version %d).", store.SchemaVersion())
+			return nil
+		}
+
+		printSuccess("Applied %d migration(s)", len(applied))
+		for _, status := range applied {
+			printField(fmt.Sprintf("v%d", status.Version), status.Name)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dbCmd)
+	dbCmd.AddCommand(dbMigrateCmd)
+
+	dbMigrateCmd.Flags().BoolVar(&dbMigrateStatus, "status", false, "show applied and pending migrations without changing anything")
+}
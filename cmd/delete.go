@@ -16,16 +16,34 @@ var (
 	deleteDays   int
 	deleteID     string
 	deleteActive string
+	deleteYes    bool
+	deleteDryRun bool
+	deleteRange  rangeFlags
+	deleteFilter logFilterFlags
 )
 
 var deleteCmd = &cobra.Command{
 	Use:   "delete",
-	Short: "Delete task logs or active tasks",
+	Short: "Move task logs or active tasks to the trash",
+	Long: `Move task logs or active tasks to the trash. Trashed items no longer show
+up in logs, dash, or exports; bring them back with tt trash restore, or remove
+them for good with tt trash empty.
+
+--all, --today, --days, and --from/--to/--range show how many logs and active tasks they would
+remove and ask before going ahead. Pass --yes to skip the question; without a
+terminal to ask on, tt refuses to run unless --yes is given. --dry-run lists
+the affected log ids and deletes nothing.`,
 	Example: `  tt delete --today
   tt delete --days 7
   tt delete --id a1b2c3d4
   tt delete --active "deep work"
-  tt delete --all`,
+  tt delete --all
+  tt delete --days 30 --client acme
+  tt delete --all --project acme/api
+  tt delete --days 7 --tag meeting
+  tt delete --range last-month --client acme
+  tt delete --days 30 --dry-run
+  tt delete --all --yes`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		_ = args
 
@@ -49,34 +67,79 @@ var deleteCmd = &cobra.Command{
 		if strings.TrimSpace(deleteActive) != "" {
 			modeCount++
 		}
+		if deleteRange.isSet() {
+			modeCount++
+		}
 
+		if machineOutput() && !deleteDryRun {
+			return fmt.Errorf("--output only applies to tt delete --dry-run")
+		}
 		if modeCount == 0 {
-			return fmt.Errorf("pick one delete mode: --all, --today, --days, --id, or --active")
+			return fmt.Errorf("pick one delete mode: --all, --today, --days, --from/--to/--range, --id, or --active")
 		}
 		if modeCount > 1 {
 			return fmt.Errorf("use only one delete mode at a time")
 		}
+		if deleteFilter.isSet() && (strings.TrimSpace(deleteID) != "" || strings.TrimSpace(deleteActive) != "") {
+			return fmt.Errorf("--project, --client, and --tag only apply to --all, --today, --days, or --from/--to/--range")
+		}
+
+		now := time.Now()
+		period, err := deleteRange.resolve(now)
+		if err != nil {
+			return err
+		}
+		startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		switch {
+		case deleteToday:
+			period.From = &startOfToday
+		case daysFlagSet:
+			since := startOfToday.AddDate(0, 0, -deleteDays)
+			period.From = &since
+		}
+		filter, err := deleteFilter.filter(period)
+		if err != nil {
+			return err
+		}
 
 		st, err := store.Open()
 		if err != nil {
 			return err
 		}
 
-		now := time.Now()
+		if deleteAll || deleteToday || daysFlagSet || deleteRange.isSet() {
+			proceed, err := confirmDelete(st, filter, deleteAll && !deleteFilter.isSet())
+			if err != nil || !proceed {
+				return err
+			}
+		}
+
 		switch {
+		case deleteAll && deleteFilter.isSet():
+			deleted, err := st.DeleteLogs(filter)
+			if err != nil {
+				return fmt.Errorf("could not delete logs: %w", err)
+			}
+			if deleted == 0 {
+				printEmpty("No matching logs found.")
+				return nil
+			}
+			printSuccess("Moved all matching logs to the trash")
+			printField("count", fmt.Sprintf("%d", deleted))
+			return nil
+
 		case deleteAll:
 			deletedLogs, deletedActive, err := st.DeleteAllData()
 			if err != nil {
 				return fmt.Errorf("could not delete all data: %w", err)
 			}
-			printSuccess("Deleted all tracked data")
+			printSuccess("Moved all tracked data to the trash")
 			printField("logs", fmt.Sprintf("%d", deletedLogs))
 			printField("active", fmt.Sprintf("%d", deletedActive))
 			return nil
 
 		case deleteToday:
-			startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-			deleted, err := st.DeleteLogsSince(startOfDay)
+			deleted, err := st.DeleteLogs(filter)
 			if err != nil {
 				return fmt.Errorf("could not delete today's logs: %w", err)
 			}
@@ -84,14 +147,12 @@ var deleteCmd = &cobra.Command{
 				printEmpty("No logs found for today.")
 				return nil
 			}
-			printSuccess("Deleted today's logs")
+			printSuccess("Moved today's logs to the trash")
 			printField("count", fmt.Sprintf("%d", deleted))
 			return nil
 
 		case daysFlagSet:
-			startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-			since := startOfToday.AddDate(0, 0, -deleteDays)
-			deleted, err := st.DeleteLogsSince(since)
+			deleted, err := st.DeleteLogs(filter)
 			if err != nil {
 				return fmt.Errorf("could not delete logs for last %d days: %w", deleteDays, err)
 			}
@@ -99,7 +160,20 @@ var deleteCmd = &cobra.Command{
 				printEmpty("No logs found in the last %d days.", deleteDays)
 				return nil
 			}
-			printSuccess("Deleted logs from today - %d days", deleteDays)
+			printSuccess("Moved logs from today - %d days to the trash", deleteDays)
+			printField("count", fmt.Sprintf("%d", deleted))
+			return nil
+
+		case deleteRange.isSet():
+			deleted, err := st.DeleteLogs(filter)
+			if err != nil {
+				return fmt.Errorf("could not delete logs: %w", err)
+			}
+			if deleted == 0 {
+				printEmpty("No logs found in that range.")
+				return nil
+			}
+			printSuccess("Moved logs in the range to the trash")
 			printField("count", fmt.Sprintf("%d", deleted))
 			return nil
 
@@ -108,13 +182,23 @@ var deleteCmd = &cobra.Command{
 			if !store.IsValidLogID(id) {
 				return fmt.Errorf("--id must be an 8-character alphanumeric value")
 			}
+			if deleteDryRun {
+				entry, err := st.GetTaskLog(id)
+				if err != nil {
+					if errors.Is(err, store.ErrLogNotFound) {
+						return fmt.Errorf("log with id %s not found", id)
+					}
+					return fmt.Errorf("could not get log %s: %w", id, err)
+				}
+				return printDeletePreview([]store.TaskLogEntry{entry}, nil)
+			}
 			if err := st.DeleteLogByID(id); err != nil {
 				if errors.Is(err, store.ErrLogNotFound) {
 					return fmt.Errorf("log with id %s not found", id)
 				}
 				return fmt.Errorf("could not delete log %s: %w", id, err)
 			}
-			printSuccess("Deleted log %s", uiID(id))
+			printSuccess("Moved log %s to the trash", uiID(id))
 			return nil
 
 		case strings.TrimSpace(deleteActive) != "":
@@ -122,13 +206,29 @@ var deleteCmd = &cobra.Command{
 			if task == "" {
 				return fmt.Errorf("--active cannot be empty")
 			}
+			if deleteDryRun {
+				activeTasks, err := st.GetActiveTasks()
+				if err != nil {
+					return fmt.Errorf("could not get active tasks: %w", err)
+				}
+				for _, active := range activeTasks {
+					if active.Name != task {
+						continue
+					}
+					if machineOutput() {
+						return renderRecords([]store.ActiveTask{active})
+					}
+					return printDeletePreview(nil, []store.ActiveTask{active})
+				}
+				return fmt.Errorf("active task %q not found", task)
+			}
 			if err := st.DeleteActiveTask(task); err != nil {
 				if errors.Is(err, store.ErrTaskNotActive) {
 					return fmt.Errorf("active task %q not found", task)
 				}
 				return fmt.Errorf("could not delete active task %q: %w", task, err)
 			}
-			printSuccess("Deleted active task %q", task)
+			printSuccess("Moved active task %q to the trash", task)
 			return nil
 		}
 
@@ -136,14 +236,89 @@ var deleteCmd = &cobra.Command{
 	},
 }
 
+// confirmDelete shows what a bulk delete would move to the trash and asks
+// before going ahead. With --dry-run it lists the logs instead and stops.
+func confirmDelete(st *store.Store, filter store.LogFilter, withActive bool) (bool, error) {
+	logCount, err := st.CountLogs(filter)
+	if err != nil {
+		return false, fmt.Errorf("could not count logs: %w", err)
+	}
+	var activeTasks []store.ActiveTask
+	if withActive {
+		activeTasks, err = st.GetActiveTasks()
+		if err != nil {
+			return false, fmt.Errorf("could not get active tasks: %w", err)
+		}
+	}
+
+	if deleteDryRun {
+		logs, err := st.GetTaskLogs(filter)
+		if err != nil {
+			return false, fmt.Errorf("could not get task logs: %w", err)
+		}
+		return false, printDeletePreview(logs, activeTasks)
+	}
+	if logCount == 0 && len(activeTasks) == 0 {
+		return true, nil
+	}
+	if deleteYes {
+		return true, nil
+	}
+	if !stdinIsTerminal() {
+		return false, fmt.Errorf("refusing to delete without confirmation. pass --yes to run non-interactively")
+	}
+
+	question := fmt.Sprintf("Move %d log(s) to the trash?", logCount)
+	if withActive {
+		question = fmt.Sprintf("Move %d log(s) and %d active task(s) to the trash?", logCount, len(activeTasks))
+	}
+	ok, err := confirm(question)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		printEmpty("Nothing deleted.")
+	}
+	return ok, nil
+}
+
+func printDeletePreview(logs []store.TaskLogEntry, activeTasks []store.ActiveTask) error {
+	if machineOutput() {
+		return renderRecords(logs)
+	}
+	if len(logs) == 0 && len(activeTasks) == 0 {
+		printEmpty("Nothing would be deleted.")
+		return nil
+	}
+
+	printSection("Would Move to Trash")
+	for _, entry := range logs {
+		fmt.Printf("# %s %s\n", uiID(entry.ID), entry.TaskName)
+		printField("start", formatDateTime(entry.StartTime))
+		printField("end", formatDateTime(entry.EndTime))
+	}
+	for _, task := range activeTasks {
+		fmt.Printf("* %s (active)\n", task.Name)
+		printField("started", formatDateTime(task.StartTime))
+	}
+	fmt.Println()
+	printInfo("Dry run: %d log(s) and %d active task(s) left untouched.", len(logs), len(activeTasks))
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(deleteCmd)
+	supportsRecords(deleteCmd)
 
 	deleteCmd.Flags().BoolVar(&deleteAll, "all", false, "delete all logs and active tasks")
 	deleteCmd.Flags().BoolVar(&deleteToday, "today", false, "delete logs for today")
 	deleteCmd.Flags().IntVar(&deleteDays, "days", 0, "delete logs from today - N days")
 	deleteCmd.Flags().StringVar(&deleteID, "id", "", "delete a specific log by id")
 	deleteCmd.Flags().StringVar(&deleteActive, "active", "", "delete an active task by name")
+	deleteCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "do not ask for confirmation")
+	deleteCmd.Flags().BoolVar(&deleteDryRun, "dry-run", false, "list what would be deleted without deleting it")
+	deleteRange.register(deleteCmd, "delete")
+	deleteFilter.register(deleteCmd, "delete")
 
 	_ = deleteCmd.RegisterFlagCompletionFunc("active", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		st, err := store.Open()
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	exportCSV       = "csv"
+	exportJSON      = "json"
+	exportICS       = "ics"
+	exportTimeclock = "timeclock"
+)
+
+var exportFormats = []string{exportCSV, exportJSON, exportICS, exportTimeclock}
+
+var (
+	exportFormat string
+	exportRange  rangeFlags
+	exportFile   string
+	exportFilter logFilterFlags
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export task logs as CSV, JSON, iCalendar, or timeclock",
+	Long: `Export logged sessions, oldest first, to stdout or a file.
+
+Formats:
+  csv        one row per session, same columns as "tt logs --separate -o csv"
+  json       one object per session, same fields as "tt logs --separate -o json"
+  ics        one VEVENT per session, for calendar apps
+  timeclock  ledger/hledger i/o lines; the account is client:project, or the
+             task when the session has no project. Pauses split a session
+             into several i/o pairs so totals match tt.
+
+--from/--to and --range select sessions that overlap the range. A date or
+range name given to --to includes all of it.`,
+	Example: `  tt export --format csv --from 2026-02-01 --to 2026-02-28 --file feb.csv
+  tt export --format json --range last-quarter --file q.json
+  tt export --format ics --project acme/api > acme.ics
+  tt export --format timeclock --client acme | hledger -f timeclock:- bal`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := strings.ToLower(strings.TrimSpace(exportFormat))
+		if !slices.Contains(exportFormats, format) {
+			return fmt.Errorf("invalid --format value %q. use %s", exportFormat, strings.Join(exportFormats, ", "))
+		}
+
+		period, err := exportRange.resolve(time.Now())
+		if err != nil {
+			return err
+		}
+		filter, err := exportFilter.filter(period)
+		if err != nil {
+			return err
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		if exportFile == "" {
+			w := bufio.NewWriter(os.Stdout)
+			if _, err := exportLogs(w, st, filter, format); err != nil {
+				return fmt.Errorf("could not export logs: %w", err)
+			}
+			if err := w.Flush(); err != nil {
+				return fmt.Errorf("could not export logs: %w", err)
+			}
+			return nil
+		}
+
+		count, err := exportToFile(exportFile, st, filter, format)
+		if err != nil {
+			return fmt.Errorf("could not export logs: %w", err)
+		}
+		printSuccess("Exported %d session(s) to %s", count, exportFile)
+		return nil
+	},
+}
+
+// exportToFile writes the export next to path and renames it into place
+// once it is complete, so a failed export never leaves a partial file.
+func exportToFile(path string, st *store.Store, filter store.LogFilter, format string) (int, error) {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return 0, fmt.Errorf("could not create export file in %s: %w", filepath.Dir(path), errors.Unwrap(err))
+	}
+	staged := file.Name()
+
+	w := bufio.NewWriter(file)
+	count, err := exportLogs(w, st, filter, format)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = file.Chmod(0644)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(staged, path)
+	}
+	if err != nil {
+		os.Remove(staged)
+		return 0, err
+	}
+	return count, nil
+}
+
+// exportLogs streams the logs matching filter to w, oldest first, and
+// returns how many were written.
+func exportLogs(w io.Writer, st *store.Store, filter store.LogFilter, format string) (int, error) {
+	var (
+		write  func(store.TaskLogEntry) error
+		finish = func() error { return nil }
+	)
+	switch format {
+	case exportCSV, exportJSON:
+		recordFormat := outputCSV
+		if format == exportJSON {
+			recordFormat = outputJSON
+		}
+		rw, err := newRecordWriter(w, recordFormat, reflect.TypeOf(store.TaskLogEntry{}))
+		if err != nil {
+			return 0, err
+		}
+		write = func(entry store.TaskLogEntry) error { return rw.write(reflect.ValueOf(entry)) }
+		finish = rw.close
+	case exportICS:
+		now := time.Now()
+		if err := writeICSLines(w, icsHeader); err != nil {
+			return 0, err
+		}
+		write = func(entry store.TaskLogEntry) error { return writeICSLines(w, icsEvent(entry, now)) }
+		finish = func() error { return writeICSLines(w, []string{"END:VCALENDAR"}) }
+	case exportTimeclock:
+		write = func(entry store.TaskLogEntry) error { return writeTimeclockEntry(w, st, entry) }
+	}
+
+	count := 0
+	err := st.EachTaskLog(filter, func(entry store.TaskLogEntry) error {
+		count++
+		return write(entry)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, finish()
+}
+
+const icsTimeLayout = "20060102T150405Z"
+
+var icsHeader = []string{
+	"BEGIN:VCALENDAR",
+	"VERSION:2.0",
+	"PRODID:-//go-timetrack//tt//EN",
+	"CALSCALE:GREGORIAN",
+}
+
+func icsEvent(entry store.TaskLogEntry, now time.Time) []string {
+	// Plain text only: ui helpers add color codes when stdout is a terminal.
+	description := "Worked " + formatDuration(time.Duration(entry.DurationSeconds)*time.Second)
+	if entry.PausedSeconds > 0 {
+		description += " (" + formatDuration(time.Duration(entry.PausedSeconds)*time.Second) + " paused)"
+	}
+	if entry.ProjectName != "" {
+		description += "\nProject: " + projectLabel(entry.ProjectName, entry.ClientName)
+	}
+	if len(entry.Tags) > 0 {
+		description += "\nTags: " + tagsLabel(entry.Tags)
+	}
+
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + entry.ID + "@tt",
+		"DTSTAMP:" + now.UTC().Format(icsTimeLayout),
+		"DTSTART:" + entry.StartTime.UTC().Format(icsTimeLayout),
+		"DTEND:" + entry.EndTime.UTC().Format(icsTimeLayout),
+		"SUMMARY:" + escapeICSText(entry.TaskName),
+		"DESCRIPTION:" + escapeICSText(description),
+	}
+	if len(entry.Tags) > 0 {
+		escaped := make([]string, len(entry.Tags))
+		for i, tag := range entry.Tags {
+			escaped[i] = escapeICSText(tag)
+		}
+		lines = append(lines, "CATEGORIES:"+strings.Join(escaped, ","))
+	}
+	return append(lines, "END:VEVENT")
+}
+
+func writeICSLines(w io.Writer, lines []string) error {
+	for _, line := range lines {
+		if _, err := io.WriteString(w, foldICSLine(line)+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func escapeICSText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// foldICSLine splits lines longer than 75 octets as RFC 5545 requires,
+// without breaking a UTF-8 sequence.
+func foldICSLine(line string) string {
+	const limit = 75
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
+
+const timeclockLayout = "2006/01/02 15:04:05"
+
+func writeTimeclockEntry(w io.Writer, st *store.Store, entry store.TaskLogEntry) error {
+	account := entry.TaskName
+	description := ""
+	if entry.ProjectName != "" {
+		account = strings.ReplaceAll(projectLabel(entry.ProjectName, entry.ClientName), "/", ":")
+		description = entry.TaskName
+	}
+
+	pauses, err := st.GetTaskLogPauses(entry.ID)
+	if err != nil {
+		return err
+	}
+
+	start := entry.StartTime
+	for _, pause := range pauses {
+		if pause.EndTime == nil {
+			continue
+		}
+		if err := writeTimeclockPair(w, start, pause.StartTime, account, description); err != nil {
+			return err
+		}
+		start = *pause.EndTime
+	}
+	return writeTimeclockPair(w, start, entry.EndTime, account, description)
+}
+
+func writeTimeclockPair(w io.Writer, start time.Time, end time.Time, account string, description string) error {
+	if !end.After(start) {
+		return nil
+	}
+	in := fmt.Sprintf("i %s %s", start.Local().Format(timeclockLayout), account)
+	if description != "" {
+		in += "  " + description
+	}
+	_, err := fmt.Fprintf(w, "%s\no %s\n", in, end.Local().Format(timeclockLayout))
+	return err
+}
+
+func completeExportFormats(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return exportFormats, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVar(&exportFormat, "format", exportCSV, "export format: csv, json, ics, or timeclock")
+	exportRange.register(exportCmd, "export")
+	exportCmd.Flags().StringVar(&exportFile, "file", "", "write to a file instead of stdout, replaced only once the export is complete")
+	exportFilter.register(exportCmd, "export")
+
+	_ = exportCmd.RegisterFlagCompletionFunc("format", completeExportFormats)
+}
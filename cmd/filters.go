@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+// logFilterFlags holds the --project/--client/--tag flags shared by commands
+// that read or delete task logs.
+type logFilterFlags struct {
+	project string
+	client  string
+	tags    []string
+}
+
+func (f *logFilterFlags) register(cmd *cobra.Command, verb string) {
+	cmd.Flags().StringVar(&f.project, "project", "", verb+" only logs for a project (project or client/project)")
+	cmd.Flags().StringVar(&f.client, "client", "", verb+" only logs for a client")
+	cmd.Flags().StringArrayVar(&f.tags, "tag", nil, verb+" only logs with a tag (repeat to AND, 'a,b' for OR, '!a' to exclude)")
+
+	_ = cmd.RegisterFlagCompletionFunc("project", completeProjects)
+	_ = cmd.RegisterFlagCompletionFunc("client", completeClients)
+	_ = cmd.RegisterFlagCompletionFunc("tag", completeTags)
+}
+
+func (f *logFilterFlags) isSet() bool {
+	return strings.TrimSpace(f.project) != "" || strings.TrimSpace(f.client) != "" || len(f.tags) > 0
+}
+
+func (f *logFilterFlags) filter(r store.TimeRange) (store.LogFilter, error) {
+	project, err := store.ParseProjectRef(f.project)
+	if err != nil {
+		return store.LogFilter{}, err
+	}
+	tags, err := store.ParseTagFilter(f.tags)
+	if err != nil {
+		return store.LogFilter{}, err
+	}
+	return store.LogFilter{
+		Range:   r,
+		Project: project,
+		Client:  strings.TrimSpace(f.client),
+		Tags:    tags,
+	}, nil
+}
+
+func projectLabel(project string, client string) string {
+	return store.ProjectRef{Client: client, Name: project}.String()
+}
+
+func tagsLabel(tags []string) string {
+	return strings.Join(tags, ", ")
+}
+
+// groupLabel names a summary row for the chosen grouping.
+func groupLabel(by store.GroupBy, task string, project string, client string, tag string) string {
+	switch by {
+	case store.GroupByTag:
+		if tag == "" {
+			return uiMuted("(untagged)")
+		}
+		return tag
+	case store.GroupByProject:
+		if project == "" {
+			return uiMuted("(no project)")
+		}
+		return projectLabel(project, client)
+	case store.GroupByClient:
+		if client == "" {
+			return uiMuted("(no client)")
+		}
+		return client
+	default:
+		if project == "" {
+			return task
+		}
+		return task + " " + uiMuted("["+projectLabel(project, client)+"]")
+	}
+}
+
+func completeProjects(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	st, err := store.Open()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	suggestions, err := st.GetProjectSuggestions(toComplete, 20)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeClients(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	st, err := store.Open()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	suggestions, err := st.GetClientSuggestions(toComplete, 20)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	st, err := store.Open()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	suggestions, err := st.GetTagSuggestions(toComplete, 20)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeLogIDs suggests recent log ids, described by task and end time,
+// for commands that take up to maxArgs of them (0 means no limit).
+func completeLogIDs(maxArgs int) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if maxArgs > 0 && len(args) >= maxArgs {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		logs, err := st.GetRecentTaskLogs(20)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		chosen := map[string]bool{}
+		for _, arg := range args {
+			chosen[arg] = true
+		}
+
+		suggestions := make([]string, 0, len(logs))
+		for _, entry := range logs {
+			if chosen[entry.ID] {
+				continue
+			}
+			suggestions = append(suggestions, fmt.Sprintf("%s\t%s (%s)", entry.ID, entry.TaskName, formatDateTime(entry.EndTime)))
+		}
+		return suggestions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+	}
+}
+
+func completeGroupBy(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{
+		string(store.GroupByTask),
+		string(store.GroupByProject),
+		string(store.GroupByClient),
+		string(store.GroupByTag),
+	}, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var historyLimit int
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List recent changes that tt undo can revert",
+	Example: `  tt history
+  tt history --limit 50
+  tt history -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if historyLimit <= 0 {
+			return fmt.Errorf("--limit must be > 0")
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		ops, err := st.GetOperations(historyLimit)
+		if err != nil {
+			return fmt.Errorf("could not read history: %w", err)
+		}
+		if machineOutput() {
+			return renderRecords(ops)
+		}
+		if len(ops) == 0 {
+			printEmpty("No history yet.")
+			return nil
+		}
+
+		printSection("History")
+		for i, op := range ops {
+			fmt.Printf("%d) %s\n", op.ID, op.Name)
+			printField("at", formatDateTime(op.CreatedAt))
+			printField("changes", fmt.Sprintf("%d", op.Changes))
+			if op.UndoneAt != nil {
+				printField("state", uiWarn("undone "+formatDateTime(*op.UndoneAt)))
+			}
+			if i < len(ops)-1 {
+				fmt.Println()
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	supportsRecords(historyCmd)
+
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 20, "number of operations to show")
+}
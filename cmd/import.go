@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var importDryRun bool
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import sessions from other time trackers",
+	Long: `Import finished sessions from other time trackers.
+
+Every imported session remembers a hash of its source row, so running the
+same import again skips what is already there. Use --dry-run to see what
+would be added without writing anything.
+
+With --output, the added sessions are printed as records, and skipped
+sessions and overlaps with existing logs are reported on stderr.`,
+}
+
+// openImportFile opens path for reading, or returns stdin for "-".
+func openImportFile(path string) (*os.File, error) {
+	if path == "-" {
+		return os.Stdin, nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open import file: %w", err)
+	}
+	return file, nil
+}
+
+func runImport(entries []store.NewTaskLog) error {
+	if len(entries) == 0 {
+		if machineOutput() {
+			return renderRecords([]store.TaskLogEntry{})
+		}
+		printEmpty("No sessions found to import.")
+		return nil
+	}
+
+	st, err := store.Open()
+	if err != nil {
+		return err
+	}
+
+	result, err := st.ImportTaskLogs(entries, importDryRun)
+	if err != nil {
+		return fmt.Errorf("could not import sessions: %w", err)
+	}
+	if machineOutput() {
+		if err := renderRecords(nonNilEntries(result.Added)); err != nil {
+			return err
+		}
+		reportImportIssues(result)
+		return nil
+	}
+
+	printImportResult(result)
+	return nil
+}
+
+func nonNilEntries(entries []store.TaskLogEntry) []store.TaskLogEntry {
+	if entries == nil {
+		return []store.TaskLogEntry{}
+	}
+	return entries
+}
+
+// reportImportIssues writes skipped sessions and overlaps to stderr, so
+// they reach the user without mixing into the records on stdout.
+func reportImportIssues(result store.ImportResult) {
+	if len(result.Skipped) > 0 {
+		fmt.Fprintf(os.Stderr, "skipped %d session(s) already imported\n", len(result.Skipped))
+	}
+	for _, conflict := range result.Conflicts {
+		fmt.Fprintf(
+			os.Stderr,
+			"overlap by %s: imported %s %s (%s - %s) with existing %s %s (%s - %s)\n",
+			formatDuration(conflict.Overlap),
+			conflict.Other.ID,
+			conflict.Other.TaskName,
+			formatDateTime(conflict.Other.StartTime),
+			formatDateTime(conflict.Other.EndTime),
+			conflict.Log.ID,
+			conflict.Log.TaskName,
+			formatDateTime(conflict.Log.StartTime),
+			formatDateTime(conflict.Log.EndTime),
+		)
+	}
+}
+
+func printImportResult(result store.ImportResult) {
+	var total time.Duration
+	for _, entry := range result.Added {
+		total += time.Duration(entry.DurationSeconds) * time.Second
+	}
+
+	if importDryRun {
+		printSection("Import Preview (dry run)")
+		printField("would add", fmt.Sprintf("%d", len(result.Added)))
+	} else {
+		printSuccess("Imported %d session(s)", len(result.Added))
+	}
+	printField("total", formatDuration(total))
+	if len(result.Skipped) > 0 {
+		printField("skipped", fmt.Sprintf("%d already imported", len(result.Skipped)))
+	}
+	if len(result.Conflicts) > 0 {
+		printField("conflicts", uiWarn(fmt.Sprintf("%d overlap(s) with existing sessions", len(result.Conflicts))))
+	}
+
+	if importDryRun {
+		for _, entry := range result.Added {
+			line := fmt.Sprintf("  %s  %s  %s", formatDateTime(entry.StartTime), formatDuration(time.Duration(entry.DurationSeconds)*time.Second), entry.TaskName)
+			if entry.ProjectName != "" {
+				line += " " + uiMuted("("+projectLabel(entry.ProjectName, entry.ClientName)+")")
+			}
+			if len(entry.Tags) > 0 {
+				line += " " + uiMuted("["+tagsLabel(entry.Tags)+"]")
+			}
+			fmt.Println(line)
+		}
+	}
+
+	if len(result.Conflicts) > 0 {
+		fmt.Println()
+		for i, conflict := range result.Conflicts {
+			fmt.Printf("%d) %s by %s\n", i+1, uiWarn("overlap"), formatDuration(conflict.Overlap))
+			printField("existing", formatSessionRef(conflict.Log))
+			printField("imported", formatSessionRef(conflict.Other))
+		}
+		fmt.Println()
+		printInfo("Use %q to review and resolve overlaps.", "tt check")
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	supportsRecords(importCmd)
+
+	importCmd.PersistentFlags().BoolVar(&importDryRun, "dry-run", false, "show what would be imported without writing anything")
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var importTimeclockCmd = &cobra.Command{
+	Use:   "timeclock <file>",
+	Short: "Import a ledger/hledger timeclock file",
+	Long: `Import clock-in/clock-out pairs from a ledger or hledger timeclock file:
+
+  i 2026/02/16 09:00:00 acme:api  refactor auth
+  o 2026/02/16 10:30:00
+
+An account of client:project maps to the tt project client/project and the
+description becomes the task name. Without a description the account is the
+task name and no project is set, which is how "tt export --format timeclock"
+writes sessions without a project. Lines starting with ;, # or * are
+comments. Pass "-" to read from stdin.`,
+	Example: `  tt import timeclock ~/time.timeclock --dry-run
+  tt export --format timeclock | tt import timeclock -`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := openImportFile(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		entries, err := readTimeclock(file)
+		if err != nil {
+			return err
+		}
+		return runImport(entries)
+	},
+}
+
+var timeclockLayouts = []string{
+	"2006/01/02 15:04:05",
+	"2006-01-02 15:04:05",
+	"2006/01/02 15:04",
+	"2006-01-02 15:04",
+}
+
+func readTimeclock(r io.Reader) ([]store.NewTaskLog, error) {
+	var (
+		entries []store.NewTaskLog
+		clockIn *store.NewTaskLog
+		inLine  int
+		scanner = bufio.NewScanner(r)
+		lineNum = 0
+	)
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.ContainsAny(line[:1], ";#*") {
+			continue
+		}
+
+		code, rest, _ := strings.Cut(line, " ")
+		switch code {
+		case "i", "I":
+			if clockIn != nil {
+				return nil, fmt.Errorf("line %d: clock-in while the clock-in on line %d is still open", lineNum, inLine)
+			}
+			entry, err := parseTimeclockIn(rest)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			clockIn, inLine = &entry, lineNum
+		case "o", "O":
+			if clockIn == nil {
+				return nil, fmt.Errorf("line %d: clock-out without a clock-in", lineNum)
+			}
+			end, _, err := parseTimeclockTime(rest)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			if end.Before(clockIn.StartTime) {
+				return nil, fmt.Errorf("line %d: %w", lineNum, store.ErrInvalidTimeRange)
+			}
+			clockIn.EndTime = end
+			clockIn.ImportHash = store.ImportHash("timeclock", *clockIn)
+			entries = append(entries, *clockIn)
+			clockIn = nil
+		default:
+			// Other ledger directives (h, b, N, ...) carry no session data.
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if clockIn != nil {
+		return nil, fmt.Errorf("line %d: clock-in has no matching clock-out", inLine)
+	}
+
+	return entries, nil
+}
+
+func parseTimeclockIn(text string) (store.NewTaskLog, error) {
+	start, rest, err := parseTimeclockTime(text)
+	if err != nil {
+		return store.NewTaskLog{}, err
+	}
+
+	account, description, _ := strings.Cut(rest, "  ")
+	account = strings.TrimSpace(account)
+	description = strings.TrimSpace(description)
+	if account == "" {
+		return store.NewTaskLog{}, fmt.Errorf("clock-in needs an account")
+	}
+
+	entry := store.NewTaskLog{StartTime: start, TaskName: description}
+	if description == "" {
+		entry.TaskName = account
+		return entry, nil
+	}
+
+	client, project, found := strings.Cut(account, ":")
+	if !found {
+		client, project = "", account
+	}
+	entry.Project = store.ProjectRef{Client: client, Name: project}
+	return entry, nil
+}
+
+// parseTimeclockTime reads the date and time at the start of text and
+// returns what follows them.
+func parseTimeclockTime(text string) (time.Time, string, error) {
+	fields := strings.Fields(text)
+	if len(fields) < 2 {
+		return time.Time{}, "", fmt.Errorf("expected a date and time")
+	}
+	value := fields[0] + " " + fields[1]
+	for _, layout := range timeclockLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			_, rest, _ := strings.Cut(strings.TrimSpace(text), fields[1])
+			return t, strings.TrimSpace(rest), nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("invalid date and time %q", value)
+}
+
+func init() {
+	importCmd.AddCommand(importTimeclockCmd)
+	supportsRecords(importTimeclockCmd)
+}
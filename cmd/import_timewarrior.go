@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var importTimewarriorCmd = &cobra.Command{
+	Use:   "timewarrior <dir | file.data>",
+	Short: "Import Timewarrior data files",
+	Long: `Import closed intervals from Timewarrior's monthly data files
+(YYYY-MM.data, usually in ~/.timewarrior/data or ~/.local/share/timewarrior/data).
+
+The interval's annotation becomes the task name, falling back to its first
+tag; the remaining tags become tags. Open intervals are skipped. A malformed
+line aborts the import before anything is written.`,
+	Example: `  tt import timewarrior ~/.timewarrior/data --dry-run
+  tt import timewarrior ~/.timewarrior/data/2026-02.data`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files, err := timewarriorDataFiles(args[0])
+		if err != nil {
+			return err
+		}
+
+		var (
+			entries []store.NewTaskLog
+			open    int
+		)
+		for _, path := range files {
+			file, err := os.Open(path)
+			if err != nil {
+				return fmt.Errorf("could not open data file: %w", err)
+			}
+			fileEntries, fileOpen, err := readTimewarriorData(file)
+			file.Close()
+			if err != nil {
+				return fmt.Errorf("%s: %w", filepath.Base(path), err)
+			}
+			entries = append(entries, fileEntries...)
+			open += fileOpen
+		}
+
+		if open > 0 {
+			printInfo("Skipping %d open interval(s).", open)
+		}
+		return runImport(entries)
+	},
+}
+
+var timewarriorDataFile = regexp.MustCompile(`^\d{4}-\d{2}\.data$`)
+
+func timewarriorDataFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	dirEntries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+	var files []string
+	for _, entry := range dirEntries {
+		if !entry.IsDir() && timewarriorDataFile.MatchString(entry.Name()) {
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no YYYY-MM.data files found in %s", path)
+	}
+	return files, nil
+}
+
+// readTimewarriorData parses interval lines such as
+//
+//	inc 20260216T090000Z - 20260216T103000Z # api "code review" # "refactor auth"
+//
+// and returns the closed intervals plus a count of open ones.
+func readTimewarriorData(r io.Reader) ([]store.NewTaskLog, int, error) {
+	var (
+		entries []store.NewTaskLog
+		open    int
+	)
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		entry, closed, err := parseTimewarriorLine(line)
+		if err != nil {
+			return nil, 0, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if !closed {
+			open++
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return entries, open, nil
+}
+
+const timewarriorTimeLayout = "20060102T150405Z"
+
+func parseTimewarriorLine(line string) (store.NewTaskLog, bool, error) {
+	interval, rest, _ := strings.Cut(line, "#")
+	fields := strings.Fields(interval)
+	if len(fields) == 0 || fields[0] != "inc" {
+		return store.NewTaskLog{}, false, fmt.Errorf("expected an 'inc' interval")
+	}
+
+	var start, end time.Time
+	var err error
+	switch len(fields) {
+	case 2:
+		if _, err := time.Parse(timewarriorTimeLayout, fields[1]); err != nil {
+			return store.NewTaskLog{}, false, fmt.Errorf("invalid start %q", fields[1])
+		}
+		return store.NewTaskLog{}, false, nil
+	case 4:
+		if fields[2] != "-" {
+			return store.NewTaskLog{}, false, fmt.Errorf("expected 'inc <start> - <end>'")
+		}
+		if start, err = time.Parse(timewarriorTimeLayout, fields[1]); err != nil {
+			return store.NewTaskLog{}, false, fmt.Errorf("invalid start %q", fields[1])
+		}
+		if end, err = time.Parse(timewarriorTimeLayout, fields[3]); err != nil {
+			return store.NewTaskLog{}, false, fmt.Errorf("invalid end %q", fields[3])
+		}
+	default:
+		return store.NewTaskLog{}, false, fmt.Errorf("expected 'inc <start> - <end>'")
+	}
+	if end.Before(start) {
+		return store.NewTaskLog{}, false, store.ErrInvalidTimeRange
+	}
+
+	tagPart, annotationPart := cutTimewarriorAnnotation(rest)
+	rawTags, err := splitTimewarriorWords(tagPart)
+	if err != nil {
+		return store.NewTaskLog{}, false, err
+	}
+	annotation, err := splitTimewarriorWords(annotationPart)
+	if err != nil {
+		return store.NewTaskLog{}, false, err
+	}
+
+	task := strings.Join(annotation, " ")
+	if task == "" && len(rawTags) > 0 {
+		task, rawTags = rawTags[0], rawTags[1:]
+	}
+	if task == "" {
+		task = "(untagged)"
+	}
+	tags, err := store.NormalizeTags(rawTags)
+	if err != nil {
+		return store.NewTaskLog{}, false, err
+	}
+
+	entry := store.NewTaskLog{
+		TaskName:  task,
+		StartTime: start.Local(),
+		EndTime:   end.Local(),
+		Tags:      tags,
+	}
+	entry.ImportHash = store.ImportHash("timewarrior", entry)
+	return entry, true, nil
+}
+
+// cutTimewarriorAnnotation splits the tags from the annotation at the first
+// " # " outside double quotes, so a tag like "issue # 12" stays whole.
+func cutTimewarriorAnnotation(text string) (string, string) {
+	var quoted, escaped bool
+	for i := 0; i < len(text); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && text[i] == '\\':
+			escaped = true
+		case text[i] == '"':
+			quoted = !quoted
+		case !quoted && strings.HasPrefix(text[i:], " # "):
+			return text[:i], text[i+len(" # "):]
+		}
+	}
+	return text, ""
+}
+
+// splitTimewarriorWords splits on spaces, keeping double-quoted words
+// together and honoring backslash escapes inside quotes.
+func splitTimewarriorWords(text string) ([]string, error) {
+	var (
+		words   []string
+		current strings.Builder
+		quoted  bool
+		escaped bool
+		inWord  bool
+	)
+	for _, r := range text {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+			inWord = true
+		case !quoted && (r == ' ' || r == '\t'):
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in %q", strings.TrimSpace(text))
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	return words, nil
+}
+
+func init() {
+	importCmd.AddCommand(importTimewarriorCmd)
+	supportsRecords(importTimewarriorCmd)
+}
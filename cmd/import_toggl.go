@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var importTogglCmd = &cobra.Command{
+	Use:   "toggl <file.csv>",
+	Short: "Import a Toggl Track detailed CSV export",
+	Long: `Import a Toggl Track "Detailed" CSV export.
+
+Description becomes the task name (falling back to the project), Client and
+Project become client/project, and Tags become tags. Start and end come from
+the Start date/Start time and End date/End time columns, read in local time.
+Pass "-" to read the CSV from stdin.`,
+	Example: `  tt import toggl Toggl_time_entries_2026-01-01_to_2026-01-31.csv --dry-run
+  tt import toggl export.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := openImportFile(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		entries, err := readTogglCSV(file)
+		if err != nil {
+			return err
+		}
+		return runImport(entries)
+	},
+}
+
+var togglDateLayouts = []string{"2006-01-02", "01/02/2006", "02.01.2006"}
+
+var togglTimeLayouts = []string{"15:04:05", "15:04", "3:04:05 PM", "3:04 PM"}
+
+func readTogglCSV(r io.Reader) ([]store.NewTaskLog, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read Toggl CSV header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"description", "start date", "start time", "end date", "end time"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("not a Toggl CSV export: missing %q column", required)
+		}
+	}
+
+	var entries []store.NewTaskLog
+	line := 1
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		entry, err := parseTogglRecord(record, columns)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func parseTogglRecord(record []string, columns map[string]int) (store.NewTaskLog, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	start, err := parseTogglDateTime(field("start date"), field("start time"))
+	if err != nil {
+		return store.NewTaskLog{}, fmt.Errorf("invalid start: %w", err)
+	}
+	end, err := parseTogglDateTime(field("end date"), field("end time"))
+	if err != nil {
+		return store.NewTaskLog{}, fmt.Errorf("invalid end: %w", err)
+	}
+	if end.Before(start) {
+		return store.NewTaskLog{}, store.ErrInvalidTimeRange
+	}
+
+	project := store.ProjectRef{Client: field("client"), Name: field("project")}
+	if project.Name == "" {
+		project.Client = ""
+	}
+
+	task := field("description")
+	if task == "" {
+		task = project.Name
+	}
+	if task == "" {
+		task = "(no description)"
+	}
+
+	var tags []string
+	if raw := field("tags"); raw != "" {
+		tags, err = store.NormalizeTags(strings.Split(raw, ","))
+		if err != nil {
+			return store.NewTaskLog{}, err
+		}
+	}
+
+	entry := store.NewTaskLog{
+		TaskName:  task,
+		StartTime: start,
+		EndTime:   end,
+		Project:   project,
+		Tags:      tags,
+	}
+	entry.ImportHash = store.ImportHash("toggl", entry)
+	return entry, nil
+}
+
+func parseTogglDateTime(date string, clock string) (time.Time, error) {
+	for _, dateLayout := range togglDateLayouts {
+		for _, timeLayout := range togglTimeLayouts {
+			if t, err := time.ParseInLocation(dateLayout+" "+timeLayout, date+" "+clock, time.Local); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("could not read %q", strings.TrimSpace(date+" "+clock))
+}
+
+func init() {
+	importCmd.AddCommand(importTogglCmd)
+	supportsRecords(importTogglCmd)
+}
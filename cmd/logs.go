@@ -16,6 +16,10 @@ var (
 	logsWeek     bool
 	logsDays     int
 	logsSeparate bool
+	logsBy       string
+	logsEdited   bool
+	logsRange    rangeFlags
+	logsFilter   logFilterFlags
 )
 
 // logsCmd represents the logs command
@@ -26,7 +30,14 @@ var logsCmd = &cobra.Command{
   tt logs --separate
   tt logs --today
   tt logs --week
-  tt logs --days 14`,
+  tt logs --days 14
+  tt logs --range last-month
+  tt logs --from 2026-02-01 --to 2026-02-14 --separate
+  tt logs --client acme --by project
+  tt logs --project acme/api --separate
+  tt logs --tag billable --tag '!meeting'
+  tt logs --week --by tag
+  tt logs --edited --separate`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		filterCount := 0
 		if logsToday {
@@ -38,37 +49,58 @@ var logsCmd = &cobra.Command{
 		if logsDays != 0 {
 			filterCount++
 		}
+		if logsRange.isSet() {
+			filterCount++
+		}
 		if filterCount > 1 {
-			return fmt.Errorf("use only one of --today, --week, or --days")
+			return fmt.Errorf("use only one of --today, --week, --days, or --from/--to/--range")
 		}
 		if logsDays < 0 {
 			return fmt.Errorf("--days must be >= 0")
 		}
+		by, err := store.ParseGroupBy(logsBy)
+		if err != nil {
+			return err
+		}
 
 		st, err := store.Open()
 		if err != nil {
 			return err
 		}
 
-		var since *time.Time
+		var period store.TimeRange
 		now := time.Now()
 		switch {
 		case logsToday:
 			startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-			since = &startOfDay
+			period.From = &startOfDay
 		case logsWeek:
-			weekAgo := now.AddDate(0, 0, -7)
-			since = &weekAgo
+			week := startOfCurrentWeek(now)
+			period.From = &week
 		case logsDays > 0:
 			daysAgo := now.Add(-time.Duration(logsDays) * 24 * time.Hour)
-			since = &daysAgo
+			period.From = &daysAgo
+		default:
+			period, err = logsRange.resolve(now)
+			if err != nil {
+				return err
+			}
+		}
+
+		filter, err := logsFilter.filter(period)
+		if err != nil {
+			return err
 		}
+		filter.Edited = logsEdited
 
 		if logsSeparate {
-			logs, err := st.GetTaskLogs(since)
+			logs, err := st.GetTaskLogs(filter)
 			if err != nil {
 				return fmt.Errorf("could not get task logs: %w", err)
 			}
+			if machineOutput() {
+				return renderRecords(logs)
+			}
 
 			if len(logs) == 0 {
 				printEmpty("No logs found.")
@@ -77,11 +109,16 @@ var logsCmd = &cobra.Command{
 
 			printSection("Task Logs (Separate Sessions)")
 			for i, entry := range logs {
-				duration := time.Duration(entry.DurationSeconds) * time.Second
 				fmt.Printf("# %s %s\n", uiID(entry.ID), entry.TaskName)
+				if entry.ProjectName != "" {
+					printField("project", projectLabel(entry.ProjectName, entry.ClientName))
+				}
+				if len(entry.Tags) > 0 {
+					printField("tags", tagsLabel(entry.Tags))
+				}
 				printField("start", formatDateTime(entry.StartTime))
 				printField("end", formatDateTime(entry.EndTime))
-				printField("total", formatDuration(duration))
+				printField("total", formatLoggedDuration(entry.DurationSeconds, entry.PausedSeconds))
 				if i < len(logs)-1 {
 					fmt.Println()
 				}
@@ -89,10 +126,13 @@ var logsCmd = &cobra.Command{
 			return nil
 		}
 
-		groups, err := st.GetTaskLogGroups(since)
+		groups, err := st.GetTaskLogGroups(filter, by)
 		if err != nil {
 			return fmt.Errorf("could not get grouped task logs: %w", err)
 		}
+		if machineOutput() {
+			return renderRecords(groups)
+		}
 		if len(groups) == 0 {
 			printEmpty("No logs found.")
 			return nil
@@ -101,7 +141,7 @@ var logsCmd = &cobra.Command{
 		printSection("Task Logs (Grouped)")
 		for i, group := range groups {
 			total := time.Duration(group.DurationSeconds) * time.Second
-			fmt.Printf("%d) %s\n", i+1, group.TaskName)
+			fmt.Printf("%d) %s\n", i+1, groupLabel(by, group.TaskName, group.ProjectName, group.ClientName, group.Tag))
 			printField("total", formatDuration(total))
 			printField("sessions", fmt.Sprintf("%d", group.SessionCount))
 			if i < len(groups)-1 {
@@ -115,9 +155,16 @@ var logsCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(logsCmd)
+	supportsRecords(logsCmd)
 
 	logsCmd.Flags().BoolVar(&logsToday, "today", false, "show only today's logs")
-	logsCmd.Flags().BoolVar(&logsWeek, "week", false, "show logs from last 7 days")
+	logsCmd.Flags().BoolVar(&logsWeek, "week", false, "show logs from the current week, starting Monday")
 	logsCmd.Flags().IntVar(&logsDays, "days", 0, "show logs from the last N days")
 	logsCmd.Flags().BoolVar(&logsSeparate, "separate", false, "show each log session separately")
+	logsCmd.Flags().StringVar(&logsBy, "by", "task", "group logs by task, project, client, or tag")
+	logsCmd.Flags().BoolVar(&logsEdited, "edited", false, "show only logs whose start or end time was changed by hand")
+	logsRange.register(logsCmd, "show")
+	logsFilter.register(logsCmd, "show")
+
+	_ = logsCmd.RegisterFlagCompletionFunc("by", completeGroupBy)
 }
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge [log-id] [log-id]...",
+	Short: "Merge sessions of the same task into one log",
+	Long: `Merge two or more sessions of the same task and project into one log.
+
+The merged log runs from the first start to the last end. Gaps between the
+sessions are recorded as pauses, so the worked time stays the same. Sessions
+that overlap cannot be merged; fix them with "tt check --fix" first.`,
+	Example: `  tt merge a1b2c3d4 e5f6g7h8
+  tt merge a1b2c3d4 e5f6g7h8 i9j0k1l2`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeLogIDs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids := make([]string, 0, len(args))
+		for _, arg := range args {
+			id := strings.TrimSpace(arg)
+			if !store.IsValidLogID(id) {
+				return fmt.Errorf("log-id %q must be an 8-character alphanumeric value", id)
+			}
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		merged, err := st.MergeTaskLogs(ids)
+		if err != nil {
+			if errors.Is(err, store.ErrLogNotFound) {
+				return fmt.Errorf("one of the logs was not found")
+			}
+			if errors.Is(err, store.ErrMergeOverlap) {
+				return fmt.Errorf("could not merge logs: %w. resolve the overlap with \"tt check --fix\" first", err)
+			}
+			return fmt.Errorf("could not merge logs: %w", err)
+		}
+
+		if machineOutput() {
+			return renderRecords([]store.TaskLogEntry{merged})
+		}
+		printSuccess("Merged %d logs into %s (%s)", len(ids), uiID(merged.ID), merged.TaskName)
+		printField("start", formatDateTime(merged.StartTime))
+		printField("end", formatDateTime(merged.EndTime))
+		printField("total", formatLoggedDuration(merged.DurationSeconds, merged.PausedSeconds))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+	supportsRecords(mergeCmd)
+}
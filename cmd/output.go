@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputText   = "text"
+	outputJSON   = "json"
+	outputNDJSON = "ndjson"
+	outputCSV    = "csv"
+	outputTSV    = "tsv"
+)
+
+var outputFormats = []string{outputText, outputJSON, outputNDJSON, outputCSV, outputTSV}
+
+var outputFormat = outputText
+
+// recordsAnnotation marks commands that can render records. The others
+// refuse a machine format instead of printing text that looks like success.
+const recordsAnnotation = "tt.records"
+
+func supportsRecords(cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		if cmd.Annotations == nil {
+			cmd.Annotations = map[string]string{}
+		}
+		cmd.Annotations[recordsAnnotation] = "true"
+	}
+}
+
+func validateOutputFormat(cmd *cobra.Command) error {
+	known := false
+	for _, format := range outputFormats {
+		if outputFormat == format {
+			known = true
+		}
+	}
+	if !known {
+		return fmt.Errorf("invalid --output value %q. use %s", outputFormat, strings.Join(outputFormats, ", "))
+	}
+	if machineOutput() && cmd.Annotations[recordsAnnotation] == "" {
+		return fmt.Errorf("%s does not support --output %s. drop the flag to get text", cmd.CommandPath(), outputFormat)
+	}
+	return nil
+}
+
+// machineOutput reports whether commands should render records instead of
+// human text.
+func machineOutput() bool {
+	return outputFormat != outputText
+}
+
+func completeOutputFormats(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return outputFormats, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// renderRecords writes a slice of store records to stdout in the selected
+// machine format. Columns and keys come from the records' json tags.
+func renderRecords(records any) error {
+	return writeRecords(os.Stdout, outputFormat, records)
+}
+
+// renderActiveTasks renders the active tasks named in names, as they are
+// after a start, pause, or resume.
+func renderActiveTasks(st *store.Store, names ...string) error {
+	tasks, err := st.GetActiveTasks()
+	if err != nil {
+		return fmt.Errorf("could not get active tasks: %w", err)
+	}
+	records := []store.ActiveTask{}
+	for _, task := range tasks {
+		if slices.Contains(names, task.Name) {
+			records = append(records, task)
+		}
+	}
+	return renderRecords(records)
+}
+
+type recordField struct {
+	name  string
+	value reflect.Value
+}
+
+func writeRecords(w io.Writer, format string, records any) error {
+	list := reflect.ValueOf(records)
+	if list.Kind() != reflect.Slice {
+		return fmt.Errorf("cannot render %T", records)
+	}
+
+	rw, err := newRecordWriter(w, format, list.Type().Elem())
+	if err != nil {
+		return err
+	}
+	for i := 0; i < list.Len(); i++ {
+		if err := rw.write(list.Index(i)); err != nil {
+			return err
+		}
+	}
+	return rw.close()
+}
+
+// recordWriter writes records of one type one at a time, so long lists can
+// be streamed instead of collected first.
+type recordWriter struct {
+	w      io.Writer
+	format string
+	csv    *csv.Writer
+	count  int
+}
+
+func newRecordWriter(w io.Writer, format string, recordType reflect.Type) (*recordWriter, error) {
+	rw := &recordWriter{w: w, format: format}
+	switch format {
+	case outputJSON:
+		_, err := io.WriteString(w, "[")
+		return rw, err
+	case outputNDJSON:
+		return rw, nil
+	case outputCSV, outputTSV:
+		rw.csv = csv.NewWriter(w)
+		if format == outputTSV {
+			rw.csv.Comma = '\t'
+		}
+		var header []string
+		for _, field := range recordFields(reflect.New(recordType).Elem()) {
+			header = append(header, field.name)
+		}
+		return rw, rw.csv.Write(header)
+	}
+	return nil, fmt.Errorf("output format %q cannot render records", format)
+}
+
+func (rw *recordWriter) write(record reflect.Value) error {
+	rw.count++
+	if rw.csv != nil {
+		var row []string
+		for _, field := range recordFields(record) {
+			row = append(row, formatRecordValue(field.value))
+		}
+		return rw.csv.Write(row)
+	}
+
+	var buf bytes.Buffer
+	if rw.format == outputJSON {
+		if rw.count > 1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n  ")
+	}
+	if err := writeJSONRecord(&buf, recordFields(record)); err != nil {
+		return err
+	}
+	if rw.format == outputNDJSON {
+		buf.WriteString("\n")
+	}
+	_, err := rw.w.Write(buf.Bytes())
+	return err
+}
+
+func (rw *recordWriter) close() error {
+	switch {
+	case rw.csv != nil:
+		rw.csv.Flush()
+		return rw.csv.Error()
+	case rw.format == outputJSON:
+		end := "]\n"
+		if rw.count > 0 {
+			end = "\n" + end
+		}
+		_, err := io.WriteString(rw.w, end)
+		return err
+	}
+	return nil
+}
+
+func recordFields(record reflect.Value) []recordField {
+	var fields []recordField
+	recordType := record.Type()
+	for i := 0; i < recordType.NumField(); i++ {
+		name, _, _ := strings.Cut(recordType.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields = append(fields, recordField{name: name, value: record.Field(i)})
+	}
+	return fields
+}
+
+func writeJSONRecord(buf *bytes.Buffer, fields []recordField) error {
+	buf.WriteString("{")
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		key, _ := json.Marshal(field.name)
+		buf.Write(key)
+		buf.WriteString(":")
+
+		value, err := json.Marshal(jsonRecordValue(field.value))
+		if err != nil {
+			return err
+		}
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return nil
+}
+
+// jsonRecordValue keeps the schema stable: times are RFC 3339 strings and
+// empty lists are [] rather than null.
+func jsonRecordValue(value reflect.Value) any {
+	switch v := value.Interface().(type) {
+	case time.Time:
+		return v.Local().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return v.Local().Format(time.RFC3339)
+	case []string:
+		if v == nil {
+			return []string{}
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func formatRecordValue(value reflect.Value) string {
+	switch v := value.Interface().(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Local().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Local().Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
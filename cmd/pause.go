@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var pauseAt atFlags
+
+var pauseCmd = &cobra.Command{
+	Use:   "pause [task]",
+	Short: "Pause an active task (or all running tasks)",
+	Example: `  tt pause "deep work"
+  tt pause
+  tt pause --ago 10m`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: stopCmd.ValidArgsFunction,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+		at, err := pauseAt.resolve(now)
+		if err != nil {
+			return err
+		}
+		if at.After(now) {
+			return fmt.Errorf("pause time %s is in the future", formatDateTime(at))
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			task := args[0]
+			if err := st.PauseTask(task, at); err != nil {
+				switch {
+				case errors.Is(err, store.ErrTaskNotActive):
+					return fmt.Errorf("task %q is not active", task)
+				case errors.Is(err, store.ErrTaskPaused):
+					return fmt.Errorf("task %q is already paused", task)
+				case errors.Is(err, store.ErrInvalidTimeRange):
+					return fmt.Errorf("pause time cannot be before task %q started or was last resumed", task)
+				}
+				return fmt.Errorf("could not pause task: %w", err)
+			}
+			if machineOutput() {
+				return renderActiveTasks(st, task)
+			}
+			printSuccess("Paused task %q", task)
+			printInfo("Use %q to continue.", "tt resume")
+			return nil
+		}
+
+		activeTasks, err := st.GetActiveTasks()
+		if err != nil {
+			return fmt.Errorf("could not get active tasks: %w", err)
+		}
+
+		var paused []string
+		for _, task := range activeTasks {
+			if task.PausedAt != nil {
+				continue
+			}
+			if err := st.PauseTask(task.Name, at); err != nil {
+				return fmt.Errorf("could not pause task %q: %w", task.Name, err)
+			}
+			paused = append(paused, task.Name)
+		}
+		if machineOutput() {
+			return renderActiveTasks(st, paused...)
+		}
+		if len(paused) == 0 {
+			printEmpty("No running tasks to pause.")
+			return nil
+		}
+
+		printSuccess("Paused all running tasks")
+		printField("count", fmt.Sprintf("%d", len(paused)))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pauseCmd)
+	supportsRecords(pauseCmd)
+
+	pauseAt.register(pauseCmd, "pause")
+}
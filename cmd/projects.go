@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var projectsCmd = &cobra.Command{
+	Use:     "projects",
+	Short:   "List projects and their clients",
+	Example: `  tt projects`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		projects, err := st.GetProjects()
+		if err != nil {
+			return fmt.Errorf("could not get projects: %w", err)
+		}
+		if machineOutput() {
+			return renderRecords(projects)
+		}
+		if len(projects) == 0 {
+			printEmpty("No projects yet. Use %q to create one.", `tt start "task" --project client/project`)
+			return nil
+		}
+
+		printSection("Projects")
+		for i, project := range projects {
+			fmt.Printf("%d) %s\n", i+1, projectLabel(project.Name, project.ClientName))
+			printField("total", formatDuration(time.Duration(project.DurationSeconds)*time.Second))
+			printField("sessions", fmt.Sprintf("%d", project.SessionCount))
+			if i < len(projects)-1 {
+				fmt.Println()
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(projectsCmd)
+	supportsRecords(projectsCmd)
+}
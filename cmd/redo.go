@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var redoCmd = &cobra.Command{
+	Use:   "redo",
+	Short: "Re-apply the last change reverted by tt undo",
+	Long: `Re-apply the change most recently reverted by tt undo. Any new change
+after an undo clears what can be redone.`,
+	Example: `  tt undo
+  tt redo`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		op, err := st.Redo()
+		if err != nil {
+			switch {
+			case errors.Is(err, store.ErrNothingToRedo):
+				if machineOutput() {
+					return renderRecords([]store.Operation{})
+				}
+				printEmpty("Nothing to redo.")
+				return nil
+			case errors.Is(err, store.ErrJournalConflict):
+				return fmt.Errorf("cannot redo: %w", err)
+			}
+			return fmt.Errorf("could not redo: %w", err)
+		}
+
+		if machineOutput() {
+			return renderRecords([]store.Operation{op})
+		}
+		printSuccess("Redid %s", op.Name)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(redoCmd)
+	supportsRecords(redoCmd)
+}
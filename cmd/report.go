@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Show per-day reports: a weekly timesheet grid or a daily breakdown",
+	Long: `Per-day reports over a period. Sessions that cross midnight are split,
+so each day only counts the time worked on it.
+
+Both reports cover the current week (Monday to Sunday) unless --today,
+--month, --all, or --from/--to/--range picks another period.`,
+}
+
+// reportPeriodFlags holds the period flags shared by the report subcommands.
+type reportPeriodFlags struct {
+	today bool
+	week  bool
+	month bool
+	all   bool
+	rng   rangeFlags
+}
+
+func (f *reportPeriodFlags) register(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.today, "today", false, "report on today")
+	cmd.Flags().BoolVar(&f.week, "week", false, "report on the current week, Monday to Sunday (default)")
+	cmd.Flags().BoolVar(&f.month, "month", false, "report on the current month")
+	cmd.Flags().BoolVar(&f.all, "all", false, "report on every day with logs")
+	f.rng.register(cmd, "report on")
+}
+
+// resolve returns the period to report on and its label.
+func (f *reportPeriodFlags) resolve(now time.Time) (store.TimeRange, string, error) {
+	count := 0
+	for _, set := range []bool{f.today, f.week, f.month, f.all, f.rng.isSet()} {
+		if set {
+			count++
+		}
+	}
+	if count > 1 {
+		return store.TimeRange{}, "", fmt.Errorf("use only one of --today, --week, --month, --all, or --from/--to/--range")
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch {
+	case f.rng.isSet():
+		period, err := f.rng.resolve(now)
+		if err != nil {
+			return store.TimeRange{}, "", err
+		}
+		return period, f.rng.label(), nil
+	case f.all:
+		return store.TimeRange{}, "all time", nil
+	case f.today:
+		return store.NewTimeRange(today, today.AddDate(0, 0, 1)), "today", nil
+	case f.month:
+		month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return store.NewTimeRange(month, month.AddDate(0, 1, 0)), "this month", nil
+	default:
+		week := startOfCurrentWeek(now)
+		return store.NewTimeRange(week, week.AddDate(0, 0, 7)), "this week", nil
+	}
+}
+
+func printReportHeader(title string, periodLabel string, filter store.LogFilter, flags logFilterFlags, totalSeconds int) {
+	printSection(title)
+	printField("period", periodLabel)
+	if !filter.Project.IsZero() {
+		printField("project", filter.Project.String())
+	}
+	if filter.Client != "" {
+		printField("client", filter.Client)
+	}
+	if len(flags.tags) > 0 {
+		printField("tags", tagsLabel(flags.tags))
+	}
+	printField("total", formatDuration(time.Duration(totalSeconds)*time.Second))
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+}
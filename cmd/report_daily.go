@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	dailyPeriod reportPeriodFlags
+	dailyFilter logFilterFlags
+)
+
+var reportDailyCmd = &cobra.Command{
+	Use:   "daily",
+	Short: "List each day with its sessions and subtotal",
+	Long: `List each day with time logged, its sessions in start order, and the
+day's subtotal. A session that crosses midnight is listed under both days,
+each with the time worked on that day.
+
+With --output, prints one record per session and day.`,
+	Example: `  tt report daily
+  tt report daily --range last-week
+  tt report daily --month --project acme/api
+  tt report daily --today -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		period, periodLabel, err := dailyPeriod.resolve(time.Now())
+		if err != nil {
+			return err
+		}
+		filter, err := dailyFilter.filter(period)
+		if err != nil {
+			return err
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		days, err := st.ReportDays(filter)
+		if err != nil {
+			return fmt.Errorf("could not build daily report: %w", err)
+		}
+		sessions, err := st.GetDailySessions(filter, days)
+		if err != nil {
+			return fmt.Errorf("could not build daily report: %w", err)
+		}
+		if machineOutput() {
+			return renderRecords(sessions)
+		}
+		if len(sessions) == 0 {
+			printEmpty("No logs found for %s.", periodLabel)
+			return nil
+		}
+
+		total := 0
+		for _, session := range sessions {
+			total += session.DurationSeconds
+		}
+		printReportHeader("Daily Report", periodLabel, filter, dailyFilter, total)
+
+		for start := 0; start < len(sessions); {
+			end := start
+			subtotal := 0
+			for end < len(sessions) && sessions[end].Date == sessions[start].Date {
+				subtotal += sessions[end].DurationSeconds
+				end++
+			}
+
+			day, _ := time.ParseInLocation("2006-01-02", sessions[start].Date, time.Local)
+			fmt.Println()
+			fmt.Printf("%s  %s\n", uiAccent(day.Format("Mon, Jan 2")), formatDuration(time.Duration(subtotal)*time.Second))
+			for _, session := range sessions[start:end] {
+				line := fmt.Sprintf(
+					"  %s  %s - %s  %s  %s",
+					uiID(session.ID),
+					padLeft(formatClock(session.StartTime), 8),
+					padLeft(formatClock(session.EndTime), 8),
+					padLeft(formatDuration(time.Duration(session.DurationSeconds)*time.Second), 7),
+					session.TaskName,
+				)
+				if session.ProjectName != "" {
+					line += " " + uiMuted("("+projectLabel(session.ProjectName, session.ClientName)+")")
+				}
+				if len(session.Tags) > 0 {
+					line += " " + uiMuted("["+tagsLabel(session.Tags)+"]")
+				}
+				fmt.Println(line)
+			}
+			start = end
+		}
+		return nil
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(reportDailyCmd)
+	supportsRecords(reportDailyCmd)
+
+	dailyPeriod.register(reportDailyCmd)
+	dailyFilter.register(reportDailyCmd, "report on")
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	timesheetBy     string
+	timesheetPeriod reportPeriodFlags
+	timesheetFilter logFilterFlags
+)
+
+var reportTimesheetCmd = &cobra.Command{
+	Use:   "timesheet",
+	Short: "Show a grid of time per task and day, with row and column totals",
+	Long: `Show a grid with one row per task (or project, client, or tag with --by)
+and one column per day, Monday to Sunday, plus row and column totals. Longer
+periods print one grid per week. Cells are hours:minutes.
+
+With --output, prints one record per group and day that has time logged.`,
+	Example: `  tt report timesheet --week
+  tt report timesheet --range last-week --by project
+  tt report timesheet --month --client acme
+  tt report timesheet --week -o csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		by, err := store.ParseGroupBy(timesheetBy)
+		if err != nil {
+			return err
+		}
+		period, periodLabel, err := timesheetPeriod.resolve(time.Now())
+		if err != nil {
+			return err
+		}
+		filter, err := timesheetFilter.filter(period)
+		if err != nil {
+			return err
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		days, err := st.ReportDays(filter)
+		if err != nil {
+			return fmt.Errorf("could not build timesheet: %w", err)
+		}
+		cells, err := st.GetTimesheet(filter, by, days)
+		if err != nil {
+			return fmt.Errorf("could not build timesheet: %w", err)
+		}
+		if machineOutput() {
+			return renderRecords(cells)
+		}
+		if len(cells) == 0 {
+			printEmpty("No logs found for %s.", periodLabel)
+			return nil
+		}
+
+		total := 0
+		for _, cell := range cells {
+			total += cell.DurationSeconds
+		}
+		printReportHeader("Timesheet", periodLabel, filter, timesheetFilter, total)
+
+		for _, week := range splitWeeks(days) {
+			weekCells := cellsOn(cells, week)
+			if len(weekCells) == 0 {
+				continue
+			}
+			fmt.Println()
+			printTimesheetWeek(week, weekCells, by)
+		}
+		return nil
+	},
+}
+
+// splitWeeks groups consecutive days into Monday-to-Sunday weeks.
+func splitWeeks(days []store.TimeRange) [][]store.TimeRange {
+	var weeks [][]store.TimeRange
+	for i, day := range days {
+		if i == 0 || day.From.Weekday() == time.Monday {
+			weeks = append(weeks, nil)
+		}
+		weeks[len(weeks)-1] = append(weeks[len(weeks)-1], day)
+	}
+	return weeks
+}
+
+func cellsOn(cells []store.TimesheetCell, days []store.TimeRange) []store.TimesheetCell {
+	dates := map[string]bool{}
+	for _, day := range days {
+		dates[day.From.Format("2006-01-02")] = true
+	}
+	var out []store.TimesheetCell
+	for _, cell := range cells {
+		if dates[cell.Date] {
+			out = append(out, cell)
+		}
+	}
+	return out
+}
+
+// printTimesheetWeek prints one week as a grid. Columns always run Monday to
+// Sunday; days outside the period are left blank.
+func printTimesheetWeek(days []store.TimeRange, cells []store.TimesheetCell, by store.GroupBy) {
+	const cellWidth = 8
+
+	first := *days[0].From
+	monday := first.AddDate(0, 0, -((int(first.Weekday()) + 6) % 7))
+	var columns [7]string
+	inPeriod := map[string]bool{}
+	for i := range columns {
+		columns[i] = monday.AddDate(0, 0, i).Format("2006-01-02")
+	}
+	for _, day := range days {
+		inPeriod[day.From.Format("2006-01-02")] = true
+	}
+
+	type row struct {
+		label   string
+		seconds [7]int
+		total   int
+	}
+	var (
+		rows    []*row
+		byLabel = map[string]*row{}
+		totals  [7]int
+		grand   int
+	)
+	for _, cell := range cells {
+		label := groupLabel(by, cell.TaskName, cell.ProjectName, cell.ClientName, cell.Tag)
+		r, ok := byLabel[label]
+		if !ok {
+			r = &row{label: label}
+			byLabel[label] = r
+			rows = append(rows, r)
+		}
+		for i, date := range columns {
+			if date == cell.Date {
+				r.seconds[i] += cell.DurationSeconds
+				r.total += cell.DurationSeconds
+				totals[i] += cell.DurationSeconds
+				grand += cell.DurationSeconds
+			}
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].total != rows[j].total {
+			return rows[i].total > rows[j].total
+		}
+		return rows[i].label < rows[j].label
+	})
+
+	labelWidth := len("Total")
+	for _, r := range rows {
+		labelWidth = max(labelWidth, visibleWidth(r.label))
+	}
+
+	cell := func(i int, seconds int) string {
+		switch {
+		case !inPeriod[columns[i]]:
+			return padLeft("", cellWidth)
+		case seconds == 0:
+			return padLeft(uiMuted("-"), cellWidth)
+		}
+		return padLeft(formatHoursMinutes(seconds), cellWidth)
+	}
+
+	_, isoWeek := monday.ISOWeek()
+	fmt.Println(uiAccent(fmt.Sprintf("Week %d: %s - %s", isoWeek, monday.Format("Jan 2"), monday.AddDate(0, 0, 6).Format("Jan 2, 2006"))))
+
+	header := padRight("", labelWidth)
+	for i := range columns {
+		header += padLeft(monday.AddDate(0, 0, i).Format("Mon 2"), cellWidth)
+	}
+	fmt.Println(uiMuted(header + padLeft("Total", cellWidth)))
+
+	for _, r := range rows {
+		line := padRight(r.label, labelWidth)
+		for i, seconds := range r.seconds {
+			line += cell(i, seconds)
+		}
+		fmt.Println(line + padLeft(formatHoursMinutes(r.total), cellWidth))
+	}
+
+	fmt.Println(uiMuted(strings.Repeat("-", labelWidth+cellWidth*8)))
+	line := padRight("Total", labelWidth)
+	for i, seconds := range totals {
+		line += cell(i, seconds)
+	}
+	fmt.Println(line + padLeft(formatHoursMinutes(grand), cellWidth))
+}
+
+// formatHoursMinutes shows seconds as h:mm, rounded to the minute.
+func formatHoursMinutes(seconds int) string {
+	minutes := (seconds + 30) / 60
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+func init() {
+	reportCmd.AddCommand(reportTimesheetCmd)
+	supportsRecords(reportTimesheetCmd)
+
+	reportTimesheetCmd.Flags().StringVar(&timesheetBy, "by", "task", "one row per task, project, client, or tag")
+	timesheetPeriod.register(reportTimesheetCmd)
+	timesheetFilter.register(reportTimesheetCmd, "report on")
+
+	_ = reportTimesheetCmd.RegisterFlagCompletionFunc("by", completeGroupBy)
+}
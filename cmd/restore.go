@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	restoreMerge   bool
+	restoreReplace bool
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Load a JSON backup written by tt backup",
+	Long: `Load a backup written by tt backup. Pass "-" to read from stdin.
+
+--merge (the default) adds logs, active tasks, projects, tags, and settings
+that are missing and keeps everything already in the database. Logs whose id
+already exists are skipped.
+
+--replace deletes all current data first, so the database ends up exactly
+as it was when the backup was taken. It also clears the undo history, since
+it refers to the data being replaced; "tt backups" keeps a snapshot of it.
+
+The backup is checked in full before anything is written, and the restore
+runs in a single transaction.`,
+	Example: `  tt restore ~/tt-backup.json
+  tt restore ~/tt-backup.json --replace`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if restoreMerge && restoreReplace {
+			return fmt.Errorf("use only one of --merge or --replace")
+		}
+		mode := store.RestoreMerge
+		if restoreReplace {
+			mode = store.RestoreReplace
+		}
+
+		file, err := openImportFile(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return fmt.Errorf("could not read backup: %w", err)
+		}
+		var backup store.Backup
+		if err := json.Unmarshal(data, &backup); err != nil {
+			return fmt.Errorf("could not read backup: %w", err)
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		result, err := st.Restore(backup, mode)
+		if err != nil {
+			return fmt.Errorf("could not restore backup: %w", err)
+		}
+
+		printSuccess("Restored %s (%s)", args[0], mode)
+		printField("logs", fmt.Sprintf("%d", result.Logs))
+		printField("active", fmt.Sprintf("%d", result.ActiveTasks))
+		printField("settings", fmt.Sprintf("%d", result.Settings))
+		if result.Trash > 0 {
+			printField("trash", fmt.Sprintf("%d", result.Trash))
+		}
+		if result.Revisions > 0 {
+			printField("revisions", fmt.Sprintf("%d", result.Revisions))
+		}
+		if result.SkippedLogs > 0 || result.SkippedActive > 0 {
+			printField("skipped", fmt.Sprintf("%d log(s), %d active task(s) already present", result.SkippedLogs, result.SkippedActive))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+
+	restoreCmd.Flags().BoolVar(&restoreMerge, "merge", false, "add missing data and keep existing data (default)")
+	restoreCmd.Flags().BoolVar(&restoreReplace, "replace", false, "delete all current data before restoring")
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var resumeAt atFlags
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume [task]",
+	Short: "Resume a paused task (or all paused tasks)",
+	Example: `  tt resume "deep work"
+  tt resume
+  tt resume --at "1:30 PM"`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: stopCmd.ValidArgsFunction,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+		at, err := resumeAt.resolve(now)
+		if err != nil {
+			return err
+		}
+		if at.After(now) {
+			return fmt.Errorf("resume time %s is in the future", formatDateTime(at))
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			task := args[0]
+			paused, err := st.ResumeTask(task, at)
+			if err != nil {
+				switch {
+				case errors.Is(err, store.ErrTaskNotActive):
+					return fmt.Errorf("task %q is not active", task)
+				case errors.Is(err, store.ErrTaskNotPaused):
+					return fmt.Errorf("task %q is not paused", task)
+				case errors.Is(err, store.ErrInvalidTimeRange):
+					return fmt.Errorf("resume time cannot be before task %q was paused", task)
+				}
+				return fmt.Errorf("could not resume task: %w", err)
+			}
+			if machineOutput() {
+				return renderActiveTasks(st, task)
+			}
+			printSuccess("Resumed task %q", task)
+			printField("paused", formatDuration(paused))
+			return nil
+		}
+
+		activeTasks, err := st.GetActiveTasks()
+		if err != nil {
+			return fmt.Errorf("could not get active tasks: %w", err)
+		}
+
+		var (
+			resumed []string
+			total   time.Duration
+		)
+		for _, task := range activeTasks {
+			if task.PausedAt == nil {
+				continue
+			}
+			paused, err := st.ResumeTask(task.Name, at)
+			if err != nil {
+				return fmt.Errorf("could not resume task %q: %w", task.Name, err)
+			}
+			total += paused
+			resumed = append(resumed, task.Name)
+		}
+		if machineOutput() {
+			return renderActiveTasks(st, resumed...)
+		}
+		if len(resumed) == 0 {
+			printEmpty("No paused tasks.")
+			return nil
+		}
+
+		printSuccess("Resumed all paused tasks")
+		printField("count", fmt.Sprintf("%d", len(resumed)))
+		printField("paused", formatDuration(total))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(resumeCmd)
+	supportsRecords(resumeCmd)
+
+	resumeAt.register(resumeCmd, "resume")
+}
@@ -15,6 +15,9 @@ var rootCmd = &cobra.Command{
 	Short:        "Track work time from your terminal",
 	Long:         "TimeTrack is a lightweight CLI to start, stop, inspect, and edit task time logs.",
 	SilenceUsage: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return validateOutputFormat(cmd)
+	},
 	Example: `  tt start "project setup"
   tt status
   tt stop "project setup"
@@ -42,5 +45,7 @@ func init() {
 	// will be global for your application.
 
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.tt.yaml)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputText, "output format: text, json, ndjson, csv, or tsv")
+	_ = rootCmd.RegisterFlagCompletionFunc("output", completeOutputFormats)
 
 }
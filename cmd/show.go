@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var showHistory bool
+
+var showCmd = &cobra.Command{
+	Use:   "show [log-id]",
+	Short: "Show a task log, optionally with its edit history",
+	Long: `Show a task log. With --history, also list every change made to it by
+tt update, tt undo/redo, or tt check --fix: the old and new value, when it
+changed, and the --reason given.`,
+	Example: `  tt show a1b2c3d4
+  tt show a1b2c3d4 --history
+  tt show a1b2c3d4 --history -o csv`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeLogIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := strings.TrimSpace(args[0])
+		if !store.IsValidLogID(id) {
+			return fmt.Errorf("log-id must be an 8-character alphanumeric value")
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		entry, err := st.GetTaskLog(id)
+		if err != nil {
+			if errors.Is(err, store.ErrLogNotFound) {
+				return fmt.Errorf("log with id %s not found", id)
+			}
+			return fmt.Errorf("could not get log %s: %w", id, err)
+		}
+
+		var revisions []store.LogRevision
+		if showHistory {
+			revisions, err = st.GetTaskLogRevisions(id)
+			if err != nil {
+				return fmt.Errorf("could not get history for log %s: %w", id, err)
+			}
+		}
+		if machineOutput() {
+			if showHistory {
+				return renderRecords(revisions)
+			}
+			return renderRecords([]store.TaskLogEntry{entry})
+		}
+
+		fmt.Printf("# %s %s\n", uiID(entry.ID), entry.TaskName)
+		if entry.ProjectName != "" {
+			printField("project", projectLabel(entry.ProjectName, entry.ClientName))
+		}
+		if len(entry.Tags) > 0 {
+			printField("tags", tagsLabel(entry.Tags))
+		}
+		printField("start", formatDateTime(entry.StartTime))
+		printField("end", formatDateTime(entry.EndTime))
+		printField("total", formatLoggedDuration(entry.DurationSeconds, entry.PausedSeconds))
+		if !showHistory {
+			return nil
+		}
+
+		fmt.Println()
+		if len(revisions) == 0 {
+			printEmpty("No edits recorded for this log.")
+			return nil
+		}
+		printSection("History")
+		for i, revision := range revisions {
+			fmt.Printf("%d) %s %s\n", i+1, formatDateTime(revision.ChangedAt), revision.Field)
+			printField("from", formatRevisionValue(revision.Field, revision.OldValue))
+			printField("to", formatRevisionValue(revision.Field, revision.NewValue))
+			if revision.Reason != "" {
+				printField("reason", revision.Reason)
+			}
+			if i < len(revisions)-1 {
+				fmt.Println()
+			}
+		}
+		return nil
+	},
+}
+
+func formatRevisionValue(field string, value string) string {
+	if field == store.RevisionStart || field == store.RevisionEnd {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return formatDateTime(t)
+		}
+	}
+	if value == "" {
+		return uiMuted("(none)")
+	}
+	return value
+}
+
+func init() {
+	rootCmd.AddCommand(showCmd)
+	supportsRecords(showCmd)
+
+	showCmd.Flags().BoolVar(&showHistory, "history", false, "list the changes made to the log")
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var splitAt string
+
+var splitCmd = &cobra.Command{
+	Use:   "split [log-id]",
+	Short: "Split a task log into two sessions",
+	Example: `  tt split a1b2c3d4 --at "12:30"
+  tt split a1b2c3d4 --at "2026-02-16 12:30"`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeLogIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := strings.TrimSpace(args[0])
+		if !store.IsValidLogID(id) {
+			return fmt.Errorf("log-id must be an 8-character alphanumeric value")
+		}
+		if strings.TrimSpace(splitAt) == "" {
+			return fmt.Errorf("--at is required")
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		entry, err := st.GetTaskLog(id)
+		if err != nil {
+			if errors.Is(err, store.ErrLogNotFound) {
+				return fmt.Errorf("log with id %s not found", id)
+			}
+			return fmt.Errorf("could not get log %s: %w", id, err)
+		}
+
+		at, err := parseDateTimeValueOn(splitAt, "--at", entry.StartTime.Local())
+		if err != nil {
+			return err
+		}
+
+		first, second, err := st.SplitTaskLog(id, at)
+		if err != nil {
+			if errors.Is(err, store.ErrSplitOutOfRange) {
+				return fmt.Errorf("--at must fall between %s and %s", formatDateTime(entry.StartTime), formatDateTime(entry.EndTime))
+			}
+			return fmt.Errorf("could not split log %s: %w", id, err)
+		}
+
+		if machineOutput() {
+			return renderRecords([]store.TaskLogEntry{first, second})
+		}
+		printSuccess("Split log %s (%s)", uiID(id), entry.TaskName)
+		for _, part := range []store.TaskLogEntry{first, second} {
+			fmt.Printf("# %s %s\n", uiID(part.ID), part.TaskName)
+			printField("start", formatDateTime(part.StartTime))
+			printField("end", formatDateTime(part.EndTime))
+			printField("total", formatLoggedDuration(part.DurationSeconds, part.PausedSeconds))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(splitCmd)
+	supportsRecords(splitCmd)
+
+	splitCmd.Flags().StringVar(&splitAt, "at", "", "time to split at (clock-only times use the session's day)")
+}
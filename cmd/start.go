@@ -6,17 +6,24 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"time"
 	"tt/internal/store"
 
 	"github.com/spf13/cobra"
 )
 
+var startOpts startFlags
+
 // startCmd represents the start command
 var startCmd = &cobra.Command{
 	Use:   "start [task]",
 	Short: "Start tracking a task",
 	Example: `  tt start "deep work"
-  tt start "meeting"`,
+  tt start "meeting"
+  tt start "api refactor" --project acme/api
+  tt start "standup" --tag meeting --tag billable
+  tt start "deep work" --at "9:15 AM"
+  tt start "deep work" --ago 20m`,
 	Args:  cobra.ExactArgs(1),
 	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) > 0 {
@@ -37,26 +44,97 @@ var startCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		task := args[0]
 
+		opts, err := startOpts.options(time.Now())
+		if err != nil {
+			return err
+		}
+
 		st, err := store.Open()
 		if err != nil {
 			return err
 		}
 
-		if err := st.StartTask(task); err != nil {
+		exclusive, err := st.BoolSetting(store.SettingStartExclusive)
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", store.SettingStartExclusive, err)
+		}
+		if exclusive {
+			return switchTask(st, task, opts)
+		}
+
+		if err := st.StartTask(task, opts); err != nil {
 			if errors.Is(err, store.ErrTaskAlreadyActive) {
 				return fmt.Errorf("task %q is already active", task)
 			}
 			return fmt.Errorf("could not start task: %w", err)
 		}
 
-		printSuccess("Started task %q", task)
-		printInfo("Use %q to see active timers.", "tt status")
-		return nil
+		return printStarted(st, task, opts)
 	},
 }
 
+// startFlags holds the flags shared by start and switch.
+type startFlags struct {
+	project string
+	tags    []string
+	at      atFlags
+	future  bool
+}
+
+func (f *startFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.project, "project", "", "attach the task to a project (project or client/project)")
+	cmd.Flags().StringSliceVar(&f.tags, "tag", nil, "tag the session (repeatable)")
+	cmd.Flags().BoolVar(&f.future, "future", false, "allow a start time in the future")
+	f.at.register(cmd, "start")
+
+	_ = cmd.RegisterFlagCompletionFunc("project", completeProjects)
+	_ = cmd.RegisterFlagCompletionFunc("tag", completeTags)
+}
+
+func (f *startFlags) options(now time.Time) (store.StartOptions, error) {
+	project, err := store.ParseProjectRef(f.project)
+	if err != nil {
+		return store.StartOptions{}, err
+	}
+	tags, err := store.NormalizeTags(f.tags)
+	if err != nil {
+		return store.StartOptions{}, err
+	}
+	startTime, err := f.at.resolve(now)
+	if err != nil {
+		return store.StartOptions{}, err
+	}
+	if startTime.After(now) && !f.future {
+		return store.StartOptions{}, fmt.Errorf("start time %s is in the future. pass --future to allow it", formatDateTime(startTime))
+	}
+
+	return store.StartOptions{Project: project, Tags: tags, StartTime: startTime}, nil
+}
+
+// printStarted reports a started task, or renders it as a record.
+func printStarted(st *store.Store, task string, opts store.StartOptions) error {
+	if machineOutput() {
+		return renderActiveTasks(st, task)
+	}
+	printSuccess("Started task %q", task)
+	if !opts.Project.IsZero() {
+		printField("project", opts.Project.String())
+	}
+	if len(opts.Tags) > 0 {
+		printField("tags", tagsLabel(opts.Tags))
+	}
+	if !opts.StartTime.IsZero() {
+		printField("started", formatDateTime(opts.StartTime))
+	}
+	printInfo("Use %q to see active timers.", "tt status")
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(startCmd)
+	supportsRecords(startCmd)
+
+	startOpts.register(startCmd)
 
 	// Here you will define your flags and configuration settings.
 
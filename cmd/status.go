@@ -26,6 +26,9 @@ var statusCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("could not get active tasks: %w", err)
 		}
+		if machineOutput() {
+			return renderRecords(tasks)
+		}
 
 		if len(tasks) == 0 {
 			printEmpty("No active tasks.")
@@ -34,10 +37,23 @@ var statusCmd = &cobra.Command{
 
 		printSection("Active Tasks")
 		for i, task := range tasks {
-			running := time.Since(task.StartTime)
-			fmt.Printf("%d) %s\n", i+1, task.Name)
+			running := time.Since(task.StartTime) - time.Duration(task.PausedSeconds)*time.Second
+			name := task.Name
+			if task.PausedAt != nil {
+				name += " " + uiWarn("(paused)")
+			}
+			fmt.Printf("%d) %s\n", i+1, name)
+			if task.ProjectName != "" {
+				printField("project", projectLabel(task.ProjectName, task.ClientName))
+			}
+			if len(task.Tags) > 0 {
+				printField("tags", tagsLabel(task.Tags))
+			}
 			printField("started", formatClock(task.StartTime))
 			printField("running", formatDuration(running))
+			if task.PausedSeconds > 0 {
+				printField("paused", formatDuration(time.Duration(task.PausedSeconds)*time.Second))
+			}
 			if i < len(tasks)-1 {
 				fmt.Println()
 			}
@@ -48,6 +64,7 @@ var statusCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(statusCmd)
+	supportsRecords(statusCmd)
 
 	// Here you will define your flags and configuration settings.
 
@@ -12,12 +12,16 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var stopAt atFlags
+
 // stopCmd represents the stop command
 var stopCmd = &cobra.Command{
 	Use:   "stop [task]",
 	Short: "Stop tracking a task (or all active tasks)",
 	Example: `  tt stop "deep work"
-  tt stop`,
+  tt stop
+  tt stop "deep work" --at "6:30 PM"
+  tt stop --ago 15m`,
 	Args:  cobra.MaximumNArgs(1),
 	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) > 0 {
@@ -36,6 +40,15 @@ var stopCmd = &cobra.Command{
 		return suggestions, cobra.ShellCompDirectiveNoFileComp
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+		endTime, err := stopAt.resolve(now)
+		if err != nil {
+			return err
+		}
+		if endTime.After(now) {
+			return fmt.Errorf("stop time %s is in the future", formatDateTime(endTime))
+		}
+
 		st, err := store.Open()
 		if err != nil {
 			return err
@@ -43,16 +56,22 @@ var stopCmd = &cobra.Command{
 
 		if len(args) == 1 {
 			task := args[0]
-			duration, err := st.StopTask(task)
+			entry, err := st.StopTask(task, endTime)
 			if err != nil {
 				if errors.Is(err, store.ErrTaskNotActive) {
 					return fmt.Errorf("task %q is not active", task)
 				}
+				if errors.Is(err, store.ErrInvalidTimeRange) {
+					return fmt.Errorf("stop time cannot be before task %q started", task)
+				}
 				return fmt.Errorf("could not stop task: %w", err)
 			}
+			if machineOutput() {
+				return renderRecords([]store.TaskLogEntry{entry})
+			}
 
 			printSuccess("Stopped task %q", task)
-			printField("spent", formatDuration(duration))
+			printField("spent", formatLoggedDuration(entry.DurationSeconds, entry.PausedSeconds))
 			return nil
 		}
 
@@ -61,17 +80,35 @@ var stopCmd = &cobra.Command{
 			return fmt.Errorf("could not get active tasks: %w", err)
 		}
 		if len(activeTasks) == 0 {
+			if machineOutput() {
+				return renderRecords([]store.TaskLogEntry{})
+			}
 			printEmpty("No active tasks.")
 			return nil
 		}
 
-		var total time.Duration
+		if !endTime.IsZero() {
+			for _, task := range activeTasks {
+				if endTime.Before(task.StartTime) {
+					return fmt.Errorf("stop time cannot be before task %q started (%s)", task.Name, formatDateTime(task.StartTime))
+				}
+			}
+		}
+
+		var (
+			stopped []store.TaskLogEntry
+			total   time.Duration
+		)
 		for _, task := range activeTasks {
-			duration, err := st.StopTask(task.Name)
+			entry, err := st.StopTask(task.Name, endTime)
 			if err != nil {
 				return fmt.Errorf("could not stop task %q: %w", task.Name, err)
 			}
-			total += duration
+			stopped = append(stopped, entry)
+			total += time.Duration(entry.DurationSeconds) * time.Second
+		}
+		if machineOutput() {
+			return renderRecords(stopped)
 		}
 
 		printSuccess("Stopped all active tasks")
@@ -83,6 +120,9 @@ var stopCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(stopCmd)
+	supportsRecords(stopCmd)
+
+	stopAt.register(stopCmd, "stop")
 
 	// Here you will define your flags and configuration settings.
 
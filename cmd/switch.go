@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var switchOpts startFlags
+
+var switchCmd = &cobra.Command{
+	Use:   "switch [task]",
+	Short: "Stop all active tasks and start a new one",
+	Long: `Stop every active task and start the given one in a single step. If the
+task is already running, it keeps running and only the others are stopped;
+--project and --tag must then match it.
+
+--future only applies when nothing else is running, since the other tasks
+cannot be stopped at a time that has not happened yet.
+
+Set "tt config set start.exclusive true" to make tt start always behave
+this way.`,
+	Example: `  tt switch "code review"
+  tt switch "lunch" --ago 5m`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: startCmd.ValidArgsFunction,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		task := args[0]
+
+		opts, err := switchOpts.options(time.Now())
+		if err != nil {
+			return err
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		return switchTask(st, task, opts)
+	},
+}
+
+func switchTask(st *store.Store, task string, opts store.StartOptions) error {
+	stopped, kept, err := st.SwitchTask(task, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrTaskAlreadyActive):
+			return fmt.Errorf("task %q is already active", task)
+		case errors.Is(err, store.ErrActiveTaskChanged):
+			return fmt.Errorf("task %q is already active with a different project or tags. stop it first to change them", task)
+		case errors.Is(err, store.ErrStopInFuture):
+			return fmt.Errorf("switch time %s is in the future and would stop the other active tasks then", formatDateTime(opts.StartTime))
+		case errors.Is(err, store.ErrInvalidTimeRange):
+			return fmt.Errorf("switch time cannot be before an active task started")
+		}
+		return fmt.Errorf("could not switch to task %q: %w", task, err)
+	}
+
+	if !machineOutput() {
+		for _, s := range stopped {
+			printSuccess("Stopped task %q", s.TaskName)
+			printField("spent", formatLoggedDuration(s.DurationSeconds, s.PausedSeconds))
+		}
+	}
+	if kept {
+		if machineOutput() {
+			return renderActiveTasks(st, task)
+		}
+		printInfo("Task %q is already active and keeps running.", task)
+		return nil
+	}
+	return printStarted(st, task, opts)
+}
+
+func init() {
+	rootCmd.AddCommand(switchCmd)
+	supportsRecords(switchCmd)
+
+	switchOpts.register(switchCmd)
+}
@@ -0,0 +1,13 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package cmd
+
+import "os"
+
+func stdoutWidth() (int, bool) {
+	return 0, false
+}
+
+func isTerminal(f *os.File) bool {
+	return false
+}
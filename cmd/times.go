@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"tt/internal/store"
+	"tt/internal/timeparse"
+
+	"github.com/spf13/cobra"
+)
+
+func parseDateTimeValue(input string, flagName string) (time.Time, error) {
+	return parseTimeFlag(timeparse.Parser{}, input, flagName)
+}
+
+// parseDateTimeValueOn is parseDateTimeValue with clock-only values placed
+// on day instead of today.
+func parseDateTimeValueOn(input string, flagName string, day time.Time) (time.Time, error) {
+	return parseTimeFlag(timeparse.Parser{Day: day}, input, flagName)
+}
+
+func parseTimeFlag(parser timeparse.Parser, input string, flagName string) (time.Time, error) {
+	t, err := parser.Parse(input)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s value: %w", flagName, err)
+	}
+	return t, nil
+}
+
+// atFlags holds the --at/--ago pair used to backdate start and stop.
+type atFlags struct {
+	at  string
+	ago string
+}
+
+func (f *atFlags) register(cmd *cobra.Command, verb string) {
+	cmd.Flags().StringVar(&f.at, "at", "", verb+" at a time ('9:15 AM', 'yesterday 5pm', '2026-02-16 09:15', or relative like -20m)")
+	cmd.Flags().StringVar(&f.ago, "ago", "", verb+" this long ago (e.g. 20m, 1h30m, '90 min')")
+}
+
+// resolve returns the requested time, or the zero time when neither flag is set.
+func (f *atFlags) resolve(now time.Time) (time.Time, error) {
+	at := strings.TrimSpace(f.at)
+	ago := strings.TrimSpace(f.ago)
+	if at != "" && ago != "" {
+		return time.Time{}, fmt.Errorf("use only one of --at or --ago")
+	}
+
+	if ago != "" {
+		d, err := timeparse.ParseDuration(ago)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid --ago value: %w", err)
+		}
+		return now.Add(-d), nil
+	}
+	if at == "" {
+		return time.Time{}, nil
+	}
+	return parseTimeFlag(timeparse.Parser{Now: func() time.Time { return now }}, at, "--at")
+}
+
+// rangeFlags holds the --from/--to/--range flags shared by commands that
+// read, export, or delete task logs.
+type rangeFlags struct {
+	from  string
+	to    string
+	named string
+}
+
+func (f *rangeFlags) register(cmd *cobra.Command, verb string) {
+	cmd.Flags().StringVar(&f.from, "from", "", verb+" logs from this time on (date, date-time, or range name)")
+	cmd.Flags().StringVar(&f.to, "to", "", verb+" logs before this time (a date or range name includes all of it)")
+	cmd.Flags().StringVar(&f.named, "range", "", verb+" logs in a named range (yesterday, last-week, this-quarter, 2026-02, ...)")
+
+	for _, name := range []string{"from", "to", "range"} {
+		_ = cmd.RegisterFlagCompletionFunc(name, completeNamedRanges)
+	}
+}
+
+func (f *rangeFlags) isSet() bool {
+	return strings.TrimSpace(f.from) != "" || strings.TrimSpace(f.to) != "" || strings.TrimSpace(f.named) != ""
+}
+
+// resolve returns the selected range, or the zero range when no flag is set.
+// --from takes the start of a date or range name and --to takes its end, so
+// "--from 2026-02-01 --to 2026-02-28" covers all of February.
+func (f *rangeFlags) resolve(now time.Time) (store.TimeRange, error) {
+	from := strings.TrimSpace(f.from)
+	to := strings.TrimSpace(f.to)
+	named := strings.TrimSpace(f.named)
+	if named != "" {
+		if from != "" || to != "" {
+			return store.TimeRange{}, fmt.Errorf("use --range or --from/--to, not both")
+		}
+		return store.ParseNamedRange(named, now)
+	}
+
+	var r store.TimeRange
+	if from != "" {
+		bound, err := parseRangeBound(from, "--from", now)
+		if err != nil {
+			return store.TimeRange{}, err
+		}
+		r.From = bound.From
+	}
+	if to != "" {
+		bound, err := parseRangeBound(to, "--to", now)
+		if err != nil {
+			return store.TimeRange{}, err
+		}
+		r.To = bound.To
+	}
+	if err := r.Validate(); err != nil {
+		return store.TimeRange{}, fmt.Errorf("--from must be before --to")
+	}
+	return r, nil
+}
+
+// label describes the selected range the way it was given.
+func (f *rangeFlags) label() string {
+	if named := strings.TrimSpace(f.named); named != "" {
+		return named
+	}
+	from := strings.TrimSpace(f.from)
+	to := strings.TrimSpace(f.to)
+	switch {
+	case from == "":
+		return "until " + to
+	case to == "":
+		return "from " + from
+	default:
+		return from + " to " + to
+	}
+}
+
+// parseRangeBound reads a --from or --to value. A date or range name covers
+// its whole span; a point in time gives a range that starts and ends there.
+func parseRangeBound(input string, flagName string, now time.Time) (store.TimeRange, error) {
+	if r, err := store.ParseNamedRange(input, now); err == nil {
+		return r, nil
+	}
+	t, err := parseTimeFlag(timeparse.Parser{Now: func() time.Time { return now }}, input, flagName)
+	if err != nil {
+		return store.TimeRange{}, fmt.Errorf("%w. range names like last-week also work", err)
+	}
+	return store.NewTimeRange(t, t), nil
+}
+
+func completeNamedRanges(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return store.NamedRanges, cobra.ShellCompDirectiveNoFileComp
+}
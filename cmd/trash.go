@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"tt/internal/store"
+	"tt/internal/timeparse"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	trashRestoreAll bool
+	trashOlderThan  string
+)
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "List, restore, or empty deleted logs and active tasks",
+	Long: `tt delete moves logs and active tasks to the trash instead of removing
+them. Trashed items are hidden from logs, dash, and exports until they are
+restored, and stay in the trash until it is emptied.`,
+}
+
+var trashListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List trashed items, most recently deleted first",
+	Example: `  tt trash list`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		items, err := st.GetTrash()
+		if err != nil {
+			return fmt.Errorf("could not list trash: %w", err)
+		}
+		if machineOutput() {
+			return renderRecords(trashRecords(items))
+		}
+		if len(items) == 0 {
+			printEmpty("Trash is empty.")
+			return nil
+		}
+
+		printSection("Trash")
+		for i, item := range items {
+			fmt.Printf("%d) %s %s\n", i+1, uiID(item.ID), trashItemName(item))
+			printField("deleted", formatDateTime(item.DeletedAt))
+			switch item.Kind {
+			case store.TrashLog:
+				printField("log", uiID(item.Log.ID))
+				printField("time", fmt.Sprintf("%s - %s", formatDateTime(item.Log.StartTime), formatDateTime(item.Log.EndTime)))
+			case store.TrashActive:
+				printField("active", "started "+formatDateTime(item.Active.StartTime))
+			}
+			if i < len(items)-1 {
+				fmt.Println()
+			}
+		}
+		return nil
+	},
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <id>",
+	Short: "Put trashed items back",
+	Long: `Put a trashed item back. <id> is the trash id from tt trash list, the
+original log id, or the name of a deleted active task. A log whose id has been
+taken in the meantime comes back under a new id.`,
+	Example: `  tt trash restore a1b2c3d4
+  tt trash restore "deep work"
+  tt trash restore --all`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if trashRestoreAll {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 || trashRestoreAll {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		items, err := st.GetTrash()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		ids := make([]string, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, item.ID+"\t"+trashItemName(item))
+		}
+		return ids, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		if trashRestoreAll {
+			restored, err := st.RestoreAllTrash()
+			if err != nil {
+				return fmt.Errorf("could not restore trash: %w", err)
+			}
+			if len(restored) == 0 {
+				printEmpty("Trash is empty.")
+				return nil
+			}
+			printSuccess("Restored everything in the trash")
+			printField("count", fmt.Sprintf("%d", len(restored)))
+			return nil
+		}
+
+		ref := strings.TrimSpace(args[0])
+		item, err := st.RestoreTrash(ref)
+		if err != nil {
+			switch {
+			case errors.Is(err, store.ErrTrashNotFound):
+				return fmt.Errorf("%q not found in the trash. see tt trash list", ref)
+			case errors.Is(err, store.ErrTaskAlreadyActive):
+				return fmt.Errorf("%q is already running. stop it before restoring", ref)
+			}
+			return fmt.Errorf("could not restore %q: %w", ref, err)
+		}
+
+		switch item.Kind {
+		case store.TrashLog:
+			printSuccess("Restored log %s", uiID(item.Log.ID))
+		case store.TrashActive:
+			printSuccess("Restored active task %q", item.Active.Name)
+		}
+		return nil
+	},
+}
+
+var trashEmptyCmd = &cobra.Command{
+	Use:   "empty",
+	Short: "Permanently remove trashed items",
+	Example: `  tt trash empty
+  tt trash empty --older-than 30d`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var before *time.Time
+		if cmd.Flags().Changed("older-than") {
+			age, err := timeparse.ParseDuration(trashOlderThan)
+			if err != nil {
+				return fmt.Errorf("invalid --older-than value: %w", err)
+			}
+			cutoff := time.Now().Add(-age)
+			before = &cutoff
+		}
+
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		removed, err := st.EmptyTrash(before)
+		if err != nil {
+			return fmt.Errorf("could not empty trash: %w", err)
+		}
+		if removed == 0 {
+			printEmpty("Nothing to remove.")
+			return nil
+		}
+		printSuccess("Permanently removed trashed items")
+		printField("count", fmt.Sprintf("%d", removed))
+		return nil
+	},
+}
+
+// trashRecord is a trashed item flattened for --output. Start and end are
+// the log's times, or the start of an active task with no end.
+type trashRecord struct {
+	ID        string     `json:"id"`
+	Kind      string     `json:"kind"`
+	DeletedAt time.Time  `json:"deleted_at"`
+	LogID     string     `json:"log_id"`
+	Task      string     `json:"task"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	Project   string     `json:"project"`
+	Client    string     `json:"client"`
+	Tags      []string   `json:"tags"`
+}
+
+func trashRecords(items []store.TrashItem) []trashRecord {
+	records := make([]trashRecord, 0, len(items))
+	for _, item := range items {
+		record := trashRecord{ID: item.ID, Kind: string(item.Kind), DeletedAt: item.DeletedAt}
+		switch item.Kind {
+		case store.TrashLog:
+			end := item.Log.EndTime
+			record.LogID = item.Log.ID
+			record.Task = item.Log.TaskName
+			record.StartTime = item.Log.StartTime
+			record.EndTime = &end
+			record.Project = item.Log.Project
+			record.Client = item.Log.Client
+			record.Tags = item.Log.Tags
+		case store.TrashActive:
+			record.Task = item.Active.Name
+			record.StartTime = item.Active.StartTime
+			record.Project = item.Active.Project
+			record.Client = item.Active.Client
+			record.Tags = item.Active.Tags
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func trashItemName(item store.TrashItem) string {
+	switch item.Kind {
+	case store.TrashLog:
+		return item.Log.TaskName
+	case store.TrashActive:
+		return item.Active.Name + " (active)"
+	}
+	return string(item.Kind)
+}
+
+func init() {
+	rootCmd.AddCommand(trashCmd)
+	trashCmd.AddCommand(trashListCmd)
+	trashCmd.AddCommand(trashRestoreCmd)
+	trashCmd.AddCommand(trashEmptyCmd)
+	supportsRecords(trashListCmd)
+
+	trashRestoreCmd.Flags().BoolVar(&trashRestoreAll, "all", false, "restore everything in the trash")
+	trashEmptyCmd.Flags().StringVar(&trashOlderThan, "older-than", "", "only remove items deleted longer ago than this (e.g. 30d)")
+}
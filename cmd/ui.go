@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -24,6 +28,25 @@ func detectColorSupport() bool {
 	return info.Mode()&os.ModeCharDevice != 0
 }
 
+// stdinIsTerminal reports whether tt can prompt the user for input.
+func stdinIsTerminal() bool {
+	return isTerminal(os.Stdin)
+}
+
+// confirm asks a yes/no question on stdin. Anything but y or yes is a no.
+func confirm(question string) (bool, error) {
+	fmt.Print(uiWarn("[?] " + question + " [y/N] "))
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
 func uiColor(code string, text string) string {
 	if !uiColorEnabled {
 		return text
@@ -31,6 +54,83 @@ func uiColor(code string, text string) string {
 	return "\x1b[" + code + "m" + text + "\x1b[0m"
 }
 
+// terminalWidth returns the width of the terminal on stdout, falling back
+// to $COLUMNS and then to 80 when stdout is not a terminal.
+func terminalWidth() int {
+	if width, ok := stdoutWidth(); ok {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 80
+}
+
+// visibleWidth counts the runes in text that take up space on screen,
+// skipping the color codes added by uiColor.
+func visibleWidth(text string) int {
+	width := 0
+	inEscape := false
+	for _, r := range text {
+		switch {
+		case inEscape:
+			inEscape = r != 'm'
+		case r == '\x1b':
+			inEscape = true
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+// truncateVisible shortens text to at most width visible runes, ending it
+// with an ellipsis. Color codes are kept, and closed if the cut falls inside
+// a colored span.
+func truncateVisible(text string, width int) string {
+	if visibleWidth(text) <= width {
+		return text
+	}
+	var (
+		out      strings.Builder
+		count    int
+		inEscape bool
+		colored  bool
+	)
+	for _, r := range text {
+		switch {
+		case inEscape:
+			out.WriteRune(r)
+			inEscape = r != 'm'
+			continue
+		case r == '\x1b':
+			out.WriteRune(r)
+			inEscape = true
+			colored = true
+			continue
+		}
+		if count == width-1 {
+			break
+		}
+		out.WriteRune(r)
+		count++
+	}
+	out.WriteString("…")
+	if colored {
+		out.WriteString("\x1b[0m")
+	}
+	return out.String()
+}
+
+// padLeft and padRight pad text with spaces to width visible columns.
+func padLeft(text string, width int) string {
+	return strings.Repeat(" ", max(0, width-visibleWidth(text))) + text
+}
+
+func padRight(text string, width int) string {
+	return text + strings.Repeat(" ", max(0, width-visibleWidth(text)))
+}
+
 func uiMuted(text string) string {
 	return uiColor("90", text)
 }
@@ -82,6 +182,15 @@ func formatClock(t time.Time) string {
 	return t.Local().Format("3:04 PM")
 }
 
+// formatLoggedDuration shows a session's worked time, noting any paused time.
+func formatLoggedDuration(durationSeconds int, pausedSeconds int) string {
+	text := formatDuration(time.Duration(durationSeconds) * time.Second)
+	if pausedSeconds > 0 {
+		text += " " + uiMuted("("+formatDuration(time.Duration(pausedSeconds)*time.Second)+" paused)")
+	}
+	return text
+}
+
 func formatDuration(d time.Duration) string {
 	if d < 0 {
 		d = 0
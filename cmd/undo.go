@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"tt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Revert the last change to your data",
+	Long: `Revert the most recent start, stop, pause, resume, add, update, split,
+merge, delete, import, or overlap fix. Run it again to step further back, and
+use tt redo to re-apply what was undone. See tt history for the list.
+
+Undo refuses to run if the affected logs or tasks have changed since, for
+example after tt restore or tt backups restore.`,
+	Example: `  tt undo
+  tt history`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := store.Open()
+		if err != nil {
+			return err
+		}
+
+		op, err := st.Undo()
+		if err != nil {
+			switch {
+			case errors.Is(err, store.ErrNothingToUndo):
+				if machineOutput() {
+					return renderRecords([]store.Operation{})
+				}
+				printEmpty("Nothing to undo.")
+				return nil
+			case errors.Is(err, store.ErrJournalConflict):
+				return fmt.Errorf("cannot undo: %w", err)
+			}
+			return fmt.Errorf("could not undo: %w", err)
+		}
+
+		if machineOutput() {
+			return renderRecords([]store.Operation{op})
+		}
+		printSuccess("Undid %s", op.Name)
+		printInfo("Use %q to re-apply it.", "tt redo")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+	supportsRecords(undoCmd)
+}
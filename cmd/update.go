@@ -14,9 +14,13 @@ import (
 )
 
 var (
-	updateName  string
-	updateStart string
-	updateEnd   string
+	updateName       string
+	updateStart      string
+	updateEnd        string
+	updateProject    string
+	updateAddTags    []string
+	updateRemoveTags []string
+	updateReason     string
 )
 
 // updateCmd represents the update command
@@ -25,15 +29,29 @@ var updateCmd = &cobra.Command{
 	Short: "Update a task log entry",
 	Example: `  tt update a1b2c3d4 --name "backend sync"
   tt update a1b2c3d4 --start "2026-02-16 10:00" --end "2026-02-16 11:30"
-  tt update a1b2c3d4 --end "6:30 PM"`,
-	Args:  cobra.ExactArgs(1),
+  tt update a1b2c3d4 --end "6:30 PM"
+  tt update a1b2c3d4 --project acme/api
+  tt update a1b2c3d4 --project ""
+  tt update a1b2c3d4 --add-tag billable --remove-tag meeting
+  tt update a1b2c3d4 --start "9:00 AM" --reason "forgot to start the timer"`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := strings.TrimSpace(args[0])
 		if !store.IsValidLogID(id) {
 			return fmt.Errorf("log-id must be an 8-character alphanumeric value")
 		}
-		if updateName == "" && updateStart == "" && updateEnd == "" {
-			return fmt.Errorf("provide at least one of --name, --start, or --end")
+		projectFlagSet := cmd.Flags().Changed("project")
+		if updateName == "" && updateStart == "" && updateEnd == "" && !projectFlagSet &&
+			len(updateAddTags) == 0 && len(updateRemoveTags) == 0 {
+			return fmt.Errorf("provide at least one of --name, --start, --end, --project, --add-tag, or --remove-tag")
+		}
+		addTags, err := store.NormalizeTags(updateAddTags)
+		if err != nil {
+			return err
+		}
+		removeTags, err := store.NormalizeTags(updateRemoveTags)
+		if err != nil {
+			return err
 		}
 
 		var namePtr *string
@@ -63,12 +81,29 @@ var updateCmd = &cobra.Command{
 			endPtr = &endTime
 		}
 
+		var projectPtr *store.ProjectRef
+		if projectFlagSet {
+			project, err := store.ParseProjectRef(updateProject)
+			if err != nil {
+				return err
+			}
+			projectPtr = &project
+		}
+
 		st, err := store.Open()
 		if err != nil {
 			return err
 		}
 
-		entry, err := st.UpdateTaskLog(id, namePtr, startPtr, endPtr)
+		entry, err := st.UpdateTaskLog(id, store.TaskLogUpdate{
+			TaskName:   namePtr,
+			StartTime:  startPtr,
+			EndTime:    endPtr,
+			Project:    projectPtr,
+			AddTags:    addTags,
+			RemoveTags: removeTags,
+			Reason:     strings.TrimSpace(updateReason),
+		})
 		if err != nil {
 			if errors.Is(err, store.ErrLogNotFound) {
 				return fmt.Errorf("log with id %s not found", id)
@@ -78,50 +113,36 @@ var updateCmd = &cobra.Command{
 			}
 			return fmt.Errorf("could not update log: %w", err)
 		}
+		if machineOutput() {
+			return renderRecords([]store.TaskLogEntry{entry})
+		}
 
-		duration := time.Duration(entry.DurationSeconds) * time.Second
 		printSuccess("Updated log #%s (%s)", entry.ID, entry.TaskName)
+		if entry.ProjectName != "" {
+			printField("project", projectLabel(entry.ProjectName, entry.ClientName))
+		}
+		if len(entry.Tags) > 0 {
+			printField("tags", tagsLabel(entry.Tags))
+		}
 		printField("start", formatDateTime(entry.StartTime))
 		printField("end", formatDateTime(entry.EndTime))
-		printField("total", formatDuration(duration))
+		printField("total", formatLoggedDuration(entry.DurationSeconds, entry.PausedSeconds))
 		return nil
 	},
 }
 
-func parseDateTimeValue(input string, flagName string) (time.Time, error) {
-	layouts := []string{
-		time.RFC3339,
-		"2006-01-02 15:04",
-		"2006-01-02 3:04 PM",
-	}
-
-	for _, layout := range layouts {
-		if t, err := time.ParseInLocation(layout, input, time.Local); err == nil {
-			return t, nil
-		}
-	}
-
-	clockOnlyLayouts := []string{
-		"15:04",
-		"3:04 PM",
-	}
-	now := time.Now()
-	for _, layout := range clockOnlyLayouts {
-		if t, err := time.ParseInLocation(layout, input, time.Local); err == nil {
-			return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, time.Local), nil
-		}
-	}
-
-	return time.Time{}, fmt.Errorf(
-		"invalid %s value. use RFC3339, '2006-01-02 15:04', '2006-01-02 3:04 PM', '15:04', or '3:04 PM'",
-		flagName,
-	)
-}
-
 func init() {
 	rootCmd.AddCommand(updateCmd)
+	supportsRecords(updateCmd)
 
 	updateCmd.Flags().StringVar(&updateName, "name", "", "new task name for the log")
-	updateCmd.Flags().StringVar(&updateStart, "start", "", "new start time for the log")
-	updateCmd.Flags().StringVar(&updateEnd, "end", "", "new end time for the log")
+	updateCmd.Flags().StringVar(&updateStart, "start", "", "new start time for the log (e.g. 09:15, 'yesterday 3pm', -45m)")
+	updateCmd.Flags().StringVar(&updateEnd, "end", "", "new end time for the log (e.g. 17:30, noon, '2h ago')")
+	updateCmd.Flags().StringVar(&updateProject, "project", "", "move the log to a project (empty to clear)")
+	updateCmd.Flags().StringSliceVar(&updateAddTags, "add-tag", nil, "add a tag to the log (repeatable)")
+	updateCmd.Flags().StringSliceVar(&updateRemoveTags, "remove-tag", nil, "remove a tag from the log (repeatable)")
+	updateCmd.Flags().StringVar(&updateReason, "reason", "", "why the log was changed, kept in its history (see tt show --history)")
+	_ = updateCmd.RegisterFlagCompletionFunc("project", completeProjects)
+	_ = updateCmd.RegisterFlagCompletionFunc("add-tag", completeTags)
+	_ = updateCmd.RegisterFlagCompletionFunc("remove-tag", completeTags)
 }
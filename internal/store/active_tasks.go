@@ -1,10 +1,25 @@
 package store
 
+import "time"
+
 func (s *Store) GetActiveTasks() ([]ActiveTask, error) {
+	return getActiveTasks(s.db)
+}
+
+func getActiveTasks(q querier) ([]ActiveTask, error) {
 	var tasks []ActiveTask
 
-	rows, err := s.db.Query(
-		`SELECT task_name, start_time FROM active_task ORDER BY start_time ASC`,
+	rows, err := q.Query(
+		`SELECT active_task.task_name, active_task.start_time, COALESCE(p.name, ''), COALESCE(c.name, ''),
+			COALESCE((
+				SELECT GROUP_CONCAT(tag.name) FROM active_task_tag
+				JOIN tag ON tag.id = active_task_tag.tag_id
+				WHERE active_task_tag.task_name = active_task.task_name
+			), '')
+		 FROM active_task
+		 LEFT JOIN project p ON p.id = active_task.project_id
+		 LEFT JOIN client c ON c.id = p.client_id
+		 ORDER BY active_task.start_time ASC`,
 	)
 	if err != nil {
 		return nil, err
@@ -12,15 +27,33 @@ func (s *Store) GetActiveTasks() ([]ActiveTask, error) {
 	defer rows.Close()
 
 	for rows.Next() {
-		var task ActiveTask
-		if err := rows.Scan(&task.Name, &task.StartTime); err != nil {
+		var (
+			task ActiveTask
+			tags string
+		)
+		if err := rows.Scan(&task.Name, &task.StartTime, &task.ProjectName, &task.ClientName, &tags); err != nil {
 			return nil, err
 		}
+		task.Tags = splitTags(tags)
 		tasks = append(tasks, task)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	rows.Close()
+
+	now := time.Now()
+	for i := range tasks {
+		pauses, err := getActivePauses(q, tasks[i].Name)
+		if err != nil {
+			return nil, err
+		}
+		tasks[i].PausedSeconds = int(pausedWithin(pauses, tasks[i].StartTime, now).Seconds())
+		if len(pauses) > 0 && pauses[len(pauses)-1].EndTime == nil {
+			pausedAt := pauses[len(pauses)-1].StartTime
+			tasks[i].PausedAt = &pausedAt
+		}
+	}
 
 	return tasks, nil
 }
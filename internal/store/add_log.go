@@ -0,0 +1,129 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func (s *Store) AddTaskLog(entry NewTaskLog) (TaskLogEntry, error) {
+	added, err := s.AddTaskLogs([]NewTaskLog{entry})
+	if err != nil {
+		return TaskLogEntry{}, err
+	}
+	return added[0], nil
+}
+
+// AddTaskLogs inserts finished sessions in a single transaction, so either
+// every entry is written or none is.
+func (s *Store) AddTaskLogs(entries []NewTaskLog) ([]TaskLogEntry, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	j := newJournal(tx, fmt.Sprintf("add %d log(s)", len(entries)))
+	added := make([]TaskLogEntry, 0, len(entries))
+	for i, entry := range entries {
+		logEntry, err := insertTaskLogTx(tx, entry)
+		if err != nil {
+			tx.Rollback()
+			if len(entries) > 1 {
+				return nil, fmt.Errorf("entry %d: %w", i+1, err)
+			}
+			return nil, err
+		}
+		j.created(imageLog, logEntry.ID)
+		added = append(added, logEntry)
+	}
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+func validateTaskLog(taskName string, startTime time.Time, endTime time.Time) error {
+	if strings.TrimSpace(taskName) == "" {
+		return ErrEmptyTaskName
+	}
+	if endTime.Before(startTime) {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
+
+func insertTaskLogTx(tx *sql.Tx, entry NewTaskLog) (TaskLogEntry, error) {
+	if err := validateTaskLog(entry.TaskName, entry.StartTime, entry.EndTime); err != nil {
+		return TaskLogEntry{}, err
+	}
+
+	projectID, err := resolveProjectTx(tx, entry.Project)
+	if err != nil {
+		return TaskLogEntry{}, err
+	}
+
+	logID := entry.ID
+	if logID == "" {
+		logID, err = generateUniqueLogIDTx(tx, "task_log")
+		if err != nil {
+			return TaskLogEntry{}, err
+		}
+	} else if !IsValidLogID(logID) {
+		return TaskLogEntry{}, fmt.Errorf("%w: %q", ErrInvalidLogID, logID)
+	}
+
+	var importHash sql.NullString
+	if entry.ImportHash != "" {
+		importHash = sql.NullString{String: entry.ImportHash, Valid: true}
+	}
+
+	if _, err := tx.Exec(
+		`INSERT INTO task_log (id, task_name, start_time, end_time, duration_seconds, project_id, import_hash)
+		 VALUES (?, ?, ?, ?, 0, ?, ?)`,
+		logID,
+		entry.TaskName,
+		entry.StartTime,
+		entry.EndTime,
+		projectID,
+		importHash,
+	); err != nil {
+		return TaskLogEntry{}, err
+	}
+
+	paused, err := replaceLogPausesTx(tx, logID, entry.Pauses, entry.StartTime, entry.EndTime)
+	if err != nil {
+		return TaskLogEntry{}, err
+	}
+	durationSeconds := int((entry.EndTime.Sub(entry.StartTime) - paused).Seconds())
+	pausedSeconds := int(paused.Seconds())
+	if _, err := tx.Exec(
+		`UPDATE task_log SET duration_seconds = ?, paused_seconds = ? WHERE id = ?`,
+		durationSeconds,
+		pausedSeconds,
+		logID,
+	); err != nil {
+		return TaskLogEntry{}, err
+	}
+
+	if err := addLogTagsTx(tx, logID, entry.Tags); err != nil {
+		return TaskLogEntry{}, err
+	}
+
+	return TaskLogEntry{
+		ID:              logID,
+		TaskName:        entry.TaskName,
+		StartTime:       entry.StartTime,
+		EndTime:         entry.EndTime,
+		DurationSeconds: durationSeconds,
+		PausedSeconds:   pausedSeconds,
+		ProjectName:     entry.Project.Name,
+		ClientName:      entry.Project.Client,
+		Tags:            entry.Tags,
+	}, nil
+}
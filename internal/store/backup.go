@@ -0,0 +1,399 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	BackupFormat  = "tt-backup"
+	BackupVersion = 2
+)
+
+type RestoreMode string
+
+const (
+	// RestoreMerge adds what the database is missing and keeps everything
+	// that is already there.
+	RestoreMerge RestoreMode = "merge"
+	// RestoreReplace wipes the database and loads the backup in its place.
+	RestoreReplace RestoreMode = "replace"
+)
+
+// Backup reads every table into a Backup document from one consistent
+// snapshot.
+func (s *Store) Backup() (Backup, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Backup{}, err
+	}
+	defer tx.Rollback()
+
+	backup := Backup{
+		Format:        BackupFormat,
+		Version:       BackupVersion,
+		SchemaVersion: SchemaVersion(),
+		CreatedAt:     time.Now(),
+		Projects:      []ProjectRecord{},
+		Tags:          []string{},
+		Settings:      []SettingRecord{},
+		Revisions:     []LogRevision{},
+	}
+
+	rows, err := tx.Query(
+		`SELECT project.name, COALESCE(client.name, '') FROM project
+		 LEFT JOIN client ON client.id = project.client_id
+		 ORDER BY client.name ASC, project.name ASC`,
+	)
+	if err != nil {
+		return Backup{}, err
+	}
+	for rows.Next() {
+		var project ProjectRecord
+		if err := rows.Scan(&project.Name, &project.Client); err != nil {
+			rows.Close()
+			return Backup{}, err
+		}
+		backup.Projects = append(backup.Projects, project)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return Backup{}, err
+	}
+
+	if backup.Tags, err = scanStrings(tx.Query(`SELECT name FROM tag ORDER BY name ASC`)); err != nil {
+		return Backup{}, err
+	}
+
+	rows, err = tx.Query(`SELECT key, value FROM setting ORDER BY key ASC`)
+	if err != nil {
+		return Backup{}, err
+	}
+	for rows.Next() {
+		var setting SettingRecord
+		if err := rows.Scan(&setting.Key, &setting.Value); err != nil {
+			rows.Close()
+			return Backup{}, err
+		}
+		backup.Settings = append(backup.Settings, setting)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return Backup{}, err
+	}
+
+	if backup.ActiveTasks, err = getActiveRecords(tx); err != nil {
+		return Backup{}, err
+	}
+	if backup.Logs, err = getLogRecords(tx, LogFilter{}); err != nil {
+		return Backup{}, err
+	}
+	if backup.Trash, err = getTrash(tx, `ORDER BY julianday(deleted_at) ASC, id ASC`); err != nil {
+		return Backup{}, err
+	}
+	if backup.Trash == nil {
+		backup.Trash = []TrashItem{}
+	}
+
+	rows, err = tx.Query(
+		`SELECT id, log_id, field, old_value, new_value, changed_at, reason
+		 FROM task_log_revision
+		 ORDER BY julianday(changed_at) ASC, id ASC`,
+	)
+	if err != nil {
+		return Backup{}, err
+	}
+	for rows.Next() {
+		var revision LogRevision
+		if err := rows.Scan(
+			&revision.ID,
+			&revision.LogID,
+			&revision.Field,
+			&revision.OldValue,
+			&revision.NewValue,
+			&revision.ChangedAt,
+			&revision.Reason,
+		); err != nil {
+			rows.Close()
+			return Backup{}, err
+		}
+		backup.Revisions = append(backup.Revisions, revision)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return Backup{}, err
+	}
+	return backup, nil
+}
+
+// Restore loads a backup in one transaction. Every record is validated
+// before anything is written, so a bad document leaves the database as it
+// was. Version 1 documents, which predate revisions, are still accepted.
+func (s *Store) Restore(backup Backup, mode RestoreMode) (RestoreResult, error) {
+	if err := validateBackup(backup); err != nil {
+		return RestoreResult{}, err
+	}
+	if mode != RestoreMerge && mode != RestoreReplace {
+		return RestoreResult{}, fmt.Errorf("invalid restore mode %q", mode)
+	}
+	if err := s.snapshotBefore("restore"); err != nil {
+		return RestoreResult{}, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return RestoreResult{}, err
+	}
+
+	result, err := restoreTx(tx, backup, mode)
+	if err != nil {
+		tx.Rollback()
+		return RestoreResult{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return RestoreResult{}, err
+	}
+	return result, nil
+}
+
+func validateBackup(backup Backup) error {
+	if backup.Format != BackupFormat {
+		return fmt.Errorf("%w: not a tt backup", ErrUnsupportedBackup)
+	}
+	if backup.Version < 1 || backup.Version > BackupVersion {
+		return fmt.Errorf("%w: version %d, supported up to %d", ErrUnsupportedBackup, backup.Version, BackupVersion)
+	}
+
+	seenLogs := map[string]bool{}
+	for i, record := range backup.Logs {
+		if err := record.validate(); err != nil {
+			return fmt.Errorf("log %d: %w", i+1, err)
+		}
+		if seenLogs[record.ID] {
+			return fmt.Errorf("log %d: duplicate id %s", i+1, record.ID)
+		}
+		seenLogs[record.ID] = true
+	}
+
+	seenActive := map[string]bool{}
+	for i, record := range backup.ActiveTasks {
+		if err := record.validate(); err != nil {
+			return fmt.Errorf("active task %d: %w", i+1, err)
+		}
+		if seenActive[record.Name] {
+			return fmt.Errorf("active task %d: duplicate task %q", i+1, record.Name)
+		}
+		seenActive[record.Name] = true
+	}
+
+	for i, item := range backup.Trash {
+		var err error
+		switch {
+		case !IsValidLogID(item.ID):
+			err = fmt.Errorf("%w: %q", ErrInvalidLogID, item.ID)
+		case item.Kind == TrashLog && item.Log != nil:
+			err = item.Log.validate()
+		case item.Kind == TrashActive && item.Active != nil:
+			err = item.Active.validate()
+		default:
+			err = fmt.Errorf("unknown or empty trash item")
+		}
+		if err != nil {
+			return fmt.Errorf("trash item %d: %w", i+1, err)
+		}
+	}
+
+	for i, revision := range backup.Revisions {
+		var err error
+		switch {
+		case !IsValidLogID(revision.LogID):
+			err = fmt.Errorf("%w: %q", ErrInvalidLogID, revision.LogID)
+		case !isRevisionField(revision.Field):
+			err = fmt.Errorf("unknown field %q", revision.Field)
+		case revision.ChangedAt.IsZero():
+			err = fmt.Errorf("missing changed_at")
+		}
+		if err != nil {
+			return fmt.Errorf("revision %d: %w", i+1, err)
+		}
+	}
+
+	for _, setting := range backup.Settings {
+		spec, ok := settingSpecs[setting.Key]
+		if !ok {
+			continue
+		}
+		if err := spec.validate(setting.Value); err != nil {
+			return fmt.Errorf("setting %s: %w", setting.Key, err)
+		}
+	}
+	return nil
+}
+
+func restoreTx(tx *sql.Tx, backup Backup, mode RestoreMode) (RestoreResult, error) {
+	var result RestoreResult
+
+	if mode == RestoreReplace {
+		if err := clearAllTx(tx); err != nil {
+			return RestoreResult{}, err
+		}
+	}
+
+	for _, project := range backup.Projects {
+		if _, err := resolveProjectTx(tx, ProjectRef{Client: project.Client, Name: project.Name}); err != nil {
+			return RestoreResult{}, err
+		}
+	}
+	if _, err := resolveTagIDsTx(tx, backup.Tags); err != nil {
+		return RestoreResult{}, err
+	}
+
+	for _, setting := range backup.Settings {
+		if _, ok := settingSpecs[setting.Key]; !ok {
+			continue
+		}
+		res, err := tx.Exec(
+			`INSERT INTO setting (key, value) VALUES (?, ?) ON CONFLICT(key) DO NOTHING`,
+			setting.Key,
+			setting.Value,
+		)
+		if err != nil {
+			return RestoreResult{}, err
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			result.Settings++
+		}
+	}
+
+	for _, record := range backup.ActiveTasks {
+		err := insertActiveRecordTx(tx, record)
+		if errors.Is(err, ErrTaskAlreadyActive) {
+			result.SkippedActive++
+			continue
+		}
+		if err != nil {
+			return RestoreResult{}, fmt.Errorf("active task %q: %w", record.Name, err)
+		}
+		result.ActiveTasks++
+	}
+
+	for _, record := range backup.Logs {
+		if _, err := getTaskLog(tx, record.ID); err == nil {
+			result.SkippedLogs++
+			continue
+		} else if !errors.Is(err, ErrLogNotFound) {
+			return RestoreResult{}, err
+		}
+		if record.ImportHash != "" {
+			exists, err := importHashExistsTx(tx, record.ImportHash)
+			if err != nil {
+				return RestoreResult{}, err
+			}
+			if exists {
+				result.SkippedLogs++
+				continue
+			}
+		}
+
+		if _, err := insertTaskLogTx(tx, record.newTaskLog()); err != nil {
+			return RestoreResult{}, fmt.Errorf("log %s: %w", record.ID, err)
+		}
+		result.Logs++
+	}
+
+	for _, item := range backup.Trash {
+		existing, err := getTrash(tx, `WHERE id = ?`, item.ID)
+		if err != nil {
+			return RestoreResult{}, err
+		}
+		if len(existing) > 0 {
+			continue
+		}
+		if _, err := insertTrashTx(tx, item); err != nil {
+			return RestoreResult{}, fmt.Errorf("trash item %s: %w", item.ID, err)
+		}
+		result.Trash++
+	}
+
+	for _, revision := range backup.Revisions {
+		var exists bool
+		if err := tx.QueryRow(
+			`SELECT EXISTS (
+				SELECT 1 FROM task_log_revision
+				WHERE log_id = ? AND field = ? AND old_value = ? AND new_value = ?
+				AND julianday(changed_at) = julianday(?) AND reason = ?
+			)`,
+			revision.LogID,
+			revision.Field,
+			revision.OldValue,
+			revision.NewValue,
+			revision.ChangedAt,
+			revision.Reason,
+		).Scan(&exists); err != nil {
+			return RestoreResult{}, err
+		}
+		if exists {
+			continue
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO task_log_revision (log_id, field, old_value, new_value, changed_at, reason)
+			 VALUES (?, ?, ?, ?, ?, ?)`,
+			revision.LogID,
+			revision.Field,
+			revision.OldValue,
+			revision.NewValue,
+			revision.ChangedAt,
+			revision.Reason,
+		); err != nil {
+			return RestoreResult{}, fmt.Errorf("revision of %s: %w", revision.LogID, err)
+		}
+		result.Revisions++
+	}
+
+	return result, nil
+}
+
+// clearAllTx empties every data table, leaving only the schema. The undo
+// journal goes too: its images describe the data being replaced.
+func clearAllTx(tx *sql.Tx) error {
+	for _, table := range []string{
+		"task_log",
+		"task_log_tag",
+		"task_log_pause",
+		"task_log_revision",
+		"active_task",
+		"active_task_tag",
+		"active_task_pause",
+		"setting",
+		"project",
+		"client",
+		"tag",
+		"trash",
+		"operation_change",
+		"operation",
+	} {
+		if _, err := tx.Exec(`DELETE FROM ` + table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanStrings(rows *sql.Rows, err error) ([]string, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := []string{}
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
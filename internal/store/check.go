@@ -0,0 +1,214 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type LogIssueKind string
+
+const (
+	IssueOverlap       LogIssueKind = "overlap"
+	IssueZeroLength    LogIssueKind = "zero-length"
+	IssueSpansMidnight LogIssueKind = "spans-midnight"
+)
+
+type OverlapStrategy string
+
+const (
+	TrimEarlier  OverlapStrategy = "trim-earlier"
+	TrimLater    OverlapStrategy = "trim-later"
+	SplitOverlap OverlapStrategy = "split"
+)
+
+func ParseOverlapStrategy(value string) (OverlapStrategy, error) {
+	switch strategy := OverlapStrategy(strings.TrimSpace(value)); strategy {
+	case TrimEarlier, TrimLater, SplitOverlap:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid fix strategy %q. use trim-earlier, trim-later, or split", value)
+	}
+}
+
+// FindLogIssues reports overlapping sessions, sessions with no length, and
+// sessions that cross local midnight. Overlaps are listed once per pair with
+// the earlier-starting session as Log.
+func (s *Store) FindLogIssues() ([]LogIssue, error) {
+	logs, err := getTaskLogs(s.db, LogFilter{})
+	if err != nil {
+		return nil, err
+	}
+	sortByStart(logs)
+
+	var issues []LogIssue
+	for i, entry := range logs {
+		if !entry.EndTime.After(entry.StartTime) {
+			issues = append(issues, LogIssue{Kind: IssueZeroLength, Log: entry})
+		} else if spansMidnight(entry.StartTime, entry.EndTime) {
+			issues = append(issues, LogIssue{Kind: IssueSpansMidnight, Log: entry})
+		}
+
+		for _, later := range logs[i+1:] {
+			if !later.StartTime.Before(entry.EndTime) {
+				break
+			}
+			if overlap := overlapBetween(entry, later); overlap > 0 {
+				issues = append(issues, LogIssue{Kind: IssueOverlap, Log: entry, Other: later, Overlap: overlap})
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// FixOverlaps resolves every overlapping pair with the given strategy in one
+// transaction and returns the pairs as they look afterwards.
+//
+//   - trim-earlier ends the earlier session when the later one starts
+//   - trim-later starts the later session when the earlier one ends
+//   - split moves both to the middle of the overlap
+//
+// A session that lies wholly inside another cannot be fixed this way without
+// losing worked time, so those pairs are left alone and returned as skipped,
+// with the outer session as Log.
+func (s *Store) FixOverlaps(strategy OverlapStrategy) ([]LogIssue, []LogIssue, error) {
+	if err := s.snapshotBefore("fix overlaps"); err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logs, err := getTaskLogs(tx, LogFilter{})
+	if err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+	sortByStart(logs)
+
+	op := newJournal(tx, fixOverlapsReason+" ("+string(strategy)+")")
+	var fixed, skipped []LogIssue
+	for i := range logs {
+		// logs stays sorted by start, so only the sessions that start before
+		// this one ends can overlap it.
+		for j := i + 1; j < len(logs) && logs[j].StartTime.Before(logs[i].EndTime); {
+			earlier, later := &logs[i], &logs[j]
+			overlap := overlapBetween(*earlier, *later)
+			if overlap <= 0 {
+				j++
+				continue
+			}
+			// With equal starts the earlier session lies inside the later one,
+			// which only trim-later can handle.
+			contained := !later.EndTime.After(earlier.EndTime) ||
+				(later.StartTime.Equal(earlier.StartTime) && strategy != TrimLater)
+			if contained {
+				outer, inner := *earlier, *later
+				if later.EndTime.After(earlier.EndTime) {
+					outer, inner = inner, outer
+				}
+				skipped = append(skipped, LogIssue{Kind: IssueOverlap, Log: outer, Other: inner, Overlap: overlap})
+				j++
+				continue
+			}
+
+			switch strategy {
+			case TrimEarlier:
+				earlier.EndTime = later.StartTime
+			case TrimLater:
+				later.StartTime = earlier.EndTime
+			case SplitOverlap:
+				mid := later.StartTime.Add(earlier.EndTime.Sub(later.StartTime) / 2)
+				earlier.EndTime = mid
+				later.StartTime = mid
+			}
+
+			if err := op.touch(imageLog, earlier.ID, later.ID); err != nil {
+				tx.Rollback()
+				return nil, nil, err
+			}
+			for _, entry := range []*TaskLogEntry{earlier, later} {
+				before, err := getLogRecord(tx, entry.ID)
+				if err != nil {
+					tx.Rollback()
+					return nil, nil, err
+				}
+				entry.DurationSeconds, entry.PausedSeconds, err = setLogTimesTx(tx, entry.ID, entry.StartTime, entry.EndTime)
+				if err != nil {
+					tx.Rollback()
+					return nil, nil, err
+				}
+				after, err := getLogRecord(tx, entry.ID)
+				if err != nil {
+					tx.Rollback()
+					return nil, nil, err
+				}
+				if err := recordRevisionsTx(tx, before, after, time.Now(), fixOverlapsReason+" ("+string(strategy)+")"); err != nil {
+					tx.Rollback()
+					return nil, nil, err
+				}
+			}
+			fixed = append(fixed, LogIssue{Kind: IssueOverlap, Log: *earlier, Other: *later, Overlap: overlap})
+
+			// The later session now starts where this one ends. Move it to
+			// its place in start order; the next session slides into j.
+			if strategy != TrimEarlier {
+				moveForward(logs, j)
+			}
+		}
+	}
+
+	if err := op.record(); err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	return fixed, skipped, nil
+}
+
+// moveForward restores start order after logs[i] started later than before.
+func moveForward(logs []TaskLogEntry, i int) {
+	for ; i+1 < len(logs) && startsAfter(logs[i], logs[i+1]); i++ {
+		logs[i], logs[i+1] = logs[i+1], logs[i]
+	}
+}
+
+func startsAfter(a TaskLogEntry, b TaskLogEntry) bool {
+	if a.StartTime.Equal(b.StartTime) {
+		return a.EndTime.After(b.EndTime)
+	}
+	return a.StartTime.After(b.StartTime)
+}
+
+func sortByStart(logs []TaskLogEntry) {
+	sort.SliceStable(logs, func(i, j int) bool {
+		return startsAfter(logs[j], logs[i])
+	})
+}
+
+func overlapBetween(a TaskLogEntry, b TaskLogEntry) time.Duration {
+	start := a.StartTime
+	if b.StartTime.After(start) {
+		start = b.StartTime
+	}
+	end := a.EndTime
+	if b.EndTime.Before(end) {
+		end = b.EndTime
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+func spansMidnight(start time.Time, end time.Time) bool {
+	start = start.Local()
+	last := end.Add(-time.Nanosecond).Local()
+	return start.Year() != last.Year() || start.YearDay() != last.YearDay()
+}
@@ -1,18 +1,15 @@
 package store
 
-import "time"
-
-func (s *Store) GetTaskDurationSummary(since *time.Time) ([]TaskDurationSummary, int, error) {
-	query := `SELECT task_name, SUM(duration_seconds) as total_seconds
-		FROM task_log`
-	args := []any{}
-
-	if since != nil {
-		query += ` WHERE end_time >= ?`
-		args = append(args, *since)
-	}
-
-	query += ` GROUP BY task_name ORDER BY total_seconds DESC, task_name ASC`
+// GetTaskDurationSummary totals worked time per group. Only the part of each
+// session inside filter.Range counts.
+func (s *Store) GetTaskDurationSummary(filter LogFilter, by GroupBy) ([]TaskDurationSummary, int, error) {
+	columns, joins, groupBy := groupColumns(by)
+	seconds, args := filter.Range.workedSeconds()
+	where, whereArgs := filter.where()
+	args = append(args, whereArgs...)
+	query := `SELECT ` + columns + `, SUM(` + seconds + `) AS total_seconds
+		FROM task_log` + projectJoins + joins + where +
+		` GROUP BY ` + groupBy + ` ORDER BY total_seconds DESC, 1 ASC, 2 ASC, 3 ASC, 4 ASC`
 
 	rows, err := s.db.Query(query, args...)
 	if err != nil {
@@ -21,18 +18,26 @@ func (s *Store) GetTaskDurationSummary(since *time.Time) ([]TaskDurationSummary,
 	defer rows.Close()
 
 	var summaries []TaskDurationSummary
-	totalSeconds := 0
 	for rows.Next() {
 		var row TaskDurationSummary
-		if err := rows.Scan(&row.TaskName, &row.DurationSeconds); err != nil {
+		if err := rows.Scan(&row.TaskName, &row.ProjectName, &row.ClientName, &row.Tag, &row.DurationSeconds); err != nil {
 			return nil, 0, err
 		}
-		totalSeconds += row.DurationSeconds
 		summaries = append(summaries, row)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, 0, err
 	}
 
+	// Sessions with several tags appear in several rows, so the total comes
+	// from the logs themselves rather than from the grouped rows.
+	totalSeconds := 0
+	if err := s.db.QueryRow(
+		`SELECT COALESCE(SUM(`+seconds+`), 0) FROM task_log`+where,
+		args...,
+	).Scan(&totalSeconds); err != nil {
+		return nil, 0, err
+	}
+
 	return summaries, totalSeconds, nil
 }
@@ -1,72 +1,169 @@
 package store
 
-import "time"
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
 
-func (s *Store) DeleteLogsSince(since time.Time) (int64, error) {
-	result, err := s.db.Exec(`DELETE FROM task_log WHERE end_time >= ?`, since)
+// DeleteLogs moves the logs matching filter to the trash.
+func (s *Store) DeleteLogs(filter LogFilter) (int64, error) {
+	if err := s.snapshotBefore("delete"); err != nil {
+		return 0, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	ids, err := matchingLogIDsTx(tx, filter)
 	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	j := newJournal(tx, fmt.Sprintf("delete %d log(s)", len(ids)))
+	if err := trashLogsTx(tx, j, ids, time.Now()); err != nil {
+		tx.Rollback()
 		return 0, err
 	}
-	return result.RowsAffected()
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int64(len(ids)), nil
 }
 
+// DeleteLogByID moves one log to the trash.
 func (s *Store) DeleteLogByID(id string) error {
-	result, err := s.db.Exec(`DELETE FROM task_log WHERE id = ?`, id)
+	tx, err := s.db.Begin()
 	if err != nil {
 		return err
 	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
+
+	j := newJournal(tx, "delete log "+id)
+	if err := trashLogsTx(tx, j, []string{id}, time.Now()); err != nil {
+		tx.Rollback()
 		return err
 	}
-	if rowsAffected == 0 {
-		return ErrLogNotFound
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return err
 	}
-	return nil
+	return tx.Commit()
 }
 
+// DeleteActiveTask moves a running task to the trash.
 func (s *Store) DeleteActiveTask(task string) error {
-	result, err := s.db.Exec(`DELETE FROM active_task WHERE task_name = ?`, task)
+	tx, err := s.db.Begin()
 	if err != nil {
 		return err
 	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
+
+	j := newJournal(tx, fmt.Sprintf("delete active task %q", task))
+	if err := trashActiveTaskTx(tx, j, task, time.Now()); err != nil {
+		tx.Rollback()
 		return err
 	}
-	if rowsAffected == 0 {
-		return ErrTaskNotActive
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return err
 	}
-	return nil
+	return tx.Commit()
 }
 
+// DeleteAllData moves every log and active task to the trash.
 func (s *Store) DeleteAllData() (deletedLogs int64, deletedActive int64, err error) {
+	if err := s.snapshotBefore("delete all"); err != nil {
+		return 0, 0, err
+	}
+
 	tx, err := s.db.Begin()
 	if err != nil {
 		return 0, 0, err
 	}
 
-	logResult, err := tx.Exec(`DELETE FROM task_log`)
+	now := time.Now()
+	j := newJournal(tx, "delete all data")
+	ids, err := matchingLogIDsTx(tx, LogFilter{})
 	if err != nil {
 		tx.Rollback()
 		return 0, 0, err
 	}
-	activeResult, err := tx.Exec(`DELETE FROM active_task`)
-	if err != nil {
+	if err := trashLogsTx(tx, j, ids, now); err != nil {
 		tx.Rollback()
 		return 0, 0, err
 	}
-	if err := tx.Commit(); err != nil {
+
+	names, err := activeTaskNamesTx(tx)
+	if err != nil {
+		tx.Rollback()
 		return 0, 0, err
 	}
+	for _, name := range names {
+		if err := trashActiveTaskTx(tx, j, name, now); err != nil {
+			tx.Rollback()
+			return 0, 0, err
+		}
+	}
 
-	deletedLogs, err = logResult.RowsAffected()
-	if err != nil {
+	if err := j.record(); err != nil {
+		tx.Rollback()
 		return 0, 0, err
 	}
-	deletedActive, err = activeResult.RowsAffected()
-	if err != nil {
+	if err := tx.Commit(); err != nil {
 		return 0, 0, err
 	}
-	return deletedLogs, deletedActive, nil
+	return int64(len(ids)), int64(len(names)), nil
+}
+
+// CountLogs returns how many logs DeleteLogs would move to the trash.
+func (s *Store) CountLogs(filter LogFilter) (int64, error) {
+	where, args := filter.where()
+	var count int64
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM task_log`+where, args...).Scan(&count)
+	return count, err
+}
+
+func matchingLogIDsTx(tx *sql.Tx, filter LogFilter) ([]string, error) {
+	where, args := filter.where()
+	return scanStrings(tx.Query(`SELECT task_log.id FROM task_log`+where, args...))
+}
+
+func deleteActiveTaskTx(tx *sql.Tx, task string) error {
+	for _, q := range []string{
+		`DELETE FROM active_task WHERE task_name = ?`,
+		`DELETE FROM active_task_tag WHERE task_name = ?`,
+		`DELETE FROM active_task_pause WHERE task_name = ?`,
+	} {
+		if _, err := tx.Exec(q, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteTaskLogsTx(tx *sql.Tx, ids []string) error {
+	for _, id := range ids {
+		if _, err := tx.Exec(`DELETE FROM task_log WHERE id = ?`, id); err != nil {
+			return err
+		}
+	}
+	return deleteOrphanedLogRowsTx(tx)
+}
+
+func deleteOrphanedLogRowsTx(tx *sql.Tx) error {
+	for _, q := range []string{
+		`DELETE FROM task_log_tag WHERE log_id NOT IN (SELECT id FROM task_log)`,
+		`DELETE FROM task_log_pause WHERE log_id NOT IN (SELECT id FROM task_log)`,
+	} {
+		if _, err := tx.Exec(q); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -4,5 +4,24 @@ import "errors"
 
 var ErrTaskAlreadyActive = errors.New("task already active")
 var ErrTaskNotActive = errors.New("task not active")
+var ErrStopInFuture = errors.New("stop time is in the future")
+var ErrActiveTaskChanged = errors.New("task is already active with a different project or tags")
+var ErrTaskPaused = errors.New("task already paused")
+var ErrTaskNotPaused = errors.New("task not paused")
 var ErrLogNotFound = errors.New("log not found")
-var ErrInvalidTimeRange = errors.New("start time cannot be after end time")
\ No newline at end of file
+var ErrInvalidTimeRange = errors.New("start time cannot be after end time")
+var ErrEmptyTaskName = errors.New("task name cannot be empty")
+var ErrSplitOutOfRange = errors.New("split time must fall inside the session")
+var ErrMergeTooFew = errors.New("merge needs at least two different logs")
+var ErrMergeMismatch = errors.New("logs belong to different tasks or projects")
+var ErrMergeOverlap = errors.New("logs overlap each other")
+var ErrMergeNotAdjacent = errors.New("another session of the same task lies between the logs")
+var ErrUnknownSetting = errors.New("unknown setting")
+var ErrSchemaTooNew = errors.New("database schema is newer than this version of tt")
+var ErrInvalidLogID = errors.New("log id must be an 8-character alphanumeric value")
+var ErrUnsupportedBackup = errors.New("unsupported backup format")
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+var ErrTrashNotFound = errors.New("item not found in trash")
+var ErrNothingToUndo = errors.New("nothing to undo")
+var ErrNothingToRedo = errors.New("nothing to redo")
+var ErrJournalConflict = errors.New("data has changed since the operation")
@@ -0,0 +1,152 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LogFilter narrows queries over task_log. The zero value matches every log.
+type LogFilter struct {
+	// Range keeps logs that overlap it.
+	Range   TimeRange
+	Project ProjectRef
+	Client  string
+	Tags    TagFilter
+	// Edited keeps only logs whose start or end time was changed after
+	// they were recorded, not counting changes made by check --fix.
+	Edited bool
+}
+
+// where builds a WHERE clause over task_log. Columns are qualified with the
+// table name so the clause works both in joined SELECTs and in DELETEs.
+func (f LogFilter) where() (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if f.Range.From != nil {
+		conditions = append(conditions, `julianday(task_log.end_time) > julianday(?)`)
+		args = append(args, *f.Range.From)
+	}
+	if f.Range.To != nil {
+		conditions = append(conditions, `julianday(task_log.start_time) < julianday(?)`)
+		args = append(args, *f.Range.To)
+	}
+	if !f.Project.IsZero() {
+		condition := `task_log.project_id IN (
+			SELECT project.id FROM project
+			LEFT JOIN client ON client.id = project.client_id
+			WHERE project.name = ?`
+		args = append(args, f.Project.Name)
+		if f.Project.Client != "" {
+			condition += ` AND client.name = ?`
+			args = append(args, f.Project.Client)
+		}
+		conditions = append(conditions, condition+`)`)
+	}
+	if f.Client != "" {
+		conditions = append(conditions, `task_log.project_id IN (
+			SELECT project.id FROM project
+			JOIN client ON client.id = project.client_id
+			WHERE client.name = ?)`)
+		args = append(args, f.Client)
+	}
+	if f.Edited {
+		conditions = append(conditions, `task_log.id IN (
+			SELECT log_id FROM task_log_revision
+			WHERE field IN ('`+RevisionStart+`', '`+RevisionEnd+`') AND reason NOT LIKE ?)`)
+		args = append(args, fixOverlapsReason+" (%")
+	}
+	tagConditions, tagArgs := f.Tags.conditions()
+	conditions = append(conditions, tagConditions...)
+	args = append(args, tagArgs...)
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return ` WHERE ` + strings.Join(conditions, ` AND `), args
+}
+
+// workedSeconds returns an SQL expression for the worked seconds of each
+// task_log row that fall inside r, so a session crossing midnight only counts
+// the part on each side toward that day. Paused time inside the window is
+// left out. Sessions wholly inside r count their stored duration_seconds.
+func (r TimeRange) workedSeconds() (string, []any) {
+	if r.IsZero() {
+		return `task_log.duration_seconds`, nil
+	}
+
+	var args []any
+	// clip returns fn(julianday(column), julianday(bound)), or the column
+	// alone when the bound is open.
+	clip := func(fn string, column string, bound *time.Time) string {
+		if bound == nil {
+			return `julianday(` + column + `)`
+		}
+		args = append(args, *bound)
+		return fn + `(julianday(` + column + `), julianday(?))`
+	}
+	overlap := func(table string) string {
+		return `MAX(0, ` + clip(`MIN`, table+`.end_time`, r.To) + ` - ` + clip(`MAX`, table+`.start_time`, r.From) + `)`
+	}
+
+	var inside []string
+	if r.From != nil {
+		inside = append(inside, `julianday(task_log.start_time) >= julianday(?)`)
+		args = append(args, *r.From)
+	}
+	if r.To != nil {
+		inside = append(inside, `julianday(task_log.end_time) <= julianday(?)`)
+		args = append(args, *r.To)
+	}
+
+	return `(CASE WHEN ` + strings.Join(inside, ` AND `) + ` THEN task_log.duration_seconds
+		ELSE CAST(ROUND((` + overlap(`task_log`) + ` - COALESCE((
+			SELECT SUM(` + overlap(`pause`) + `) FROM task_log_pause pause
+			WHERE pause.log_id = task_log.id
+		), 0)) * 86400) AS INTEGER) END)`, args
+}
+
+type GroupBy string
+
+const (
+	GroupByTask    GroupBy = "task"
+	GroupByProject GroupBy = "project"
+	GroupByClient  GroupBy = "client"
+	GroupByTag     GroupBy = "tag"
+)
+
+func ParseGroupBy(value string) (GroupBy, error) {
+	switch by := GroupBy(strings.TrimSpace(value)); by {
+	case "":
+		return GroupByTask, nil
+	case GroupByTask, GroupByProject, GroupByClient, GroupByTag:
+		return by, nil
+	default:
+		return "", fmt.Errorf("invalid grouping %q. use task, project, client, or tag", value)
+	}
+}
+
+// groupColumns returns the task, project, client and tag columns to select,
+// any extra joins, and the GROUP BY expression for a grouping. Unused columns
+// are selected as empty strings. Grouping by tag counts a session once per tag.
+func groupColumns(by GroupBy) (string, string, string) {
+	switch by {
+	case GroupByProject:
+		return `'', COALESCE(p.name, ''), COALESCE(c.name, ''), ''`, ``, `task_log.project_id`
+	case GroupByClient:
+		return `'', '', COALESCE(c.name, ''), ''`, ``, `p.client_id`
+	case GroupByTag:
+		return `'', '', '', COALESCE(t.name, '')`, `
+	LEFT JOIN task_log_tag lt ON lt.log_id = task_log.id
+	LEFT JOIN tag t ON t.id = lt.tag_id`, `t.name`
+	default:
+		return `task_log.task_name, COALESCE(p.name, ''), COALESCE(c.name, ''), ''`, ``, `task_log.task_name, task_log.project_id`
+	}
+}
+
+const projectJoins = `
+	LEFT JOIN project p ON p.id = task_log.project_id
+	LEFT JOIN client c ON c.id = p.client_id`
@@ -0,0 +1,139 @@
+package store
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ImportHash derives a stable id for an imported session from its source and
+// the fields that identify it, so the same row hashes the same on every run.
+func ImportHash(source string, entry NewTaskLog) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		source,
+		strings.TrimSpace(entry.TaskName),
+		strconv.FormatInt(entry.StartTime.Unix(), 10),
+		strconv.FormatInt(entry.EndTime.Unix(), 10),
+		entry.Project.String(),
+	}, "\x1f")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// ImportTaskLogs inserts imported sessions in a single transaction, so a bad
+// entry aborts the whole import. Entries whose ImportHash is already in
+// task_log, or repeated earlier in entries, are skipped. With dryRun the
+// transaction is rolled back, so the result shows what would happen without
+// writing anything.
+func (s *Store) ImportTaskLogs(entries []NewTaskLog, dryRun bool) (ImportResult, error) {
+	if !dryRun {
+		if err := s.snapshotBefore("import"); err != nil {
+			return ImportResult{}, err
+		}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	j := newJournal(tx, fmt.Sprintf("import %d log(s)", len(entries)))
+	var result ImportResult
+	imported := map[string]bool{}
+	for i, entry := range entries {
+		if entry.ImportHash != "" {
+			exists, err := importHashExistsTx(tx, entry.ImportHash)
+			if err != nil {
+				tx.Rollback()
+				return ImportResult{}, err
+			}
+			if exists {
+				result.Skipped = append(result.Skipped, entry)
+				continue
+			}
+		}
+
+		added, err := insertTaskLogTx(tx, entry)
+		if err != nil {
+			tx.Rollback()
+			return ImportResult{}, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		result.Added = append(result.Added, added)
+		imported[added.ID] = true
+		j.created(imageLog, added.ID)
+
+		existing, err := overlappingLogsTx(tx, added)
+		if err != nil {
+			tx.Rollback()
+			return ImportResult{}, err
+		}
+		for _, other := range existing {
+			if imported[other.ID] {
+				continue
+			}
+			result.Conflicts = append(result.Conflicts, LogIssue{
+				Kind:    IssueOverlap,
+				Log:     other,
+				Other:   added,
+				Overlap: overlapBetween(other, added),
+			})
+		}
+	}
+
+	if dryRun {
+		tx.Rollback()
+		return result, nil
+	}
+	j.name = fmt.Sprintf("import %d log(s)", len(result.Added))
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return ImportResult{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return ImportResult{}, err
+	}
+	return result, nil
+}
+
+func importHashExistsTx(tx *sql.Tx, hash string) (bool, error) {
+	var id string
+	err := tx.QueryRow(`SELECT id FROM task_log WHERE import_hash = ?`, hash).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// overlappingLogsTx returns the other logs whose time range overlaps entry.
+func overlappingLogsTx(tx *sql.Tx, entry TaskLogEntry) ([]TaskLogEntry, error) {
+	rows, err := tx.Query(
+		`SELECT `+taskLogColumns+` FROM task_log`+projectJoins+`
+		 WHERE task_log.id != ?
+		   AND julianday(task_log.start_time) < julianday(?)
+		   AND julianday(task_log.end_time) > julianday(?)
+		 ORDER BY julianday(task_log.start_time) ASC`,
+		entry.ID,
+		entry.EndTime,
+		entry.StartTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []TaskLogEntry
+	for rows.Next() {
+		other, err := scanTaskLogEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, other)
+	}
+	return logs, rows.Err()
+}
@@ -0,0 +1,442 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// journalLimit is how many operations the journal keeps for undo.
+const journalLimit = 100
+
+type imageKind string
+
+const (
+	imageLog    imageKind = "log"
+	imageActive imageKind = "active"
+	imageTrash  imageKind = "trash"
+)
+
+type journalChange struct {
+	kind   imageKind
+	key    string
+	before sql.NullString
+	after  sql.NullString
+}
+
+// journal records one undoable operation. Call touch before a row changes
+// and created after a new row is inserted; record then stores the before and
+// after images of every row the operation touched.
+type journal struct {
+	tx      *sql.Tx
+	name    string
+	changes []journalChange
+	seen    map[string]bool
+}
+
+func newJournal(tx *sql.Tx, name string) *journal {
+	return &journal{tx: tx, name: name, seen: map[string]bool{}}
+}
+
+func (j *journal) touch(kind imageKind, keys ...string) error {
+	for _, key := range keys {
+		if j.seen[string(kind)+"/"+key] {
+			continue
+		}
+		before, err := readImageTx(j.tx, kind, key)
+		if err != nil {
+			return err
+		}
+		j.seen[string(kind)+"/"+key] = true
+		j.changes = append(j.changes, journalChange{kind: kind, key: key, before: before})
+	}
+	return nil
+}
+
+func (j *journal) created(kind imageKind, keys ...string) {
+	for _, key := range keys {
+		if j.seen[string(kind)+"/"+key] {
+			continue
+		}
+		j.seen[string(kind)+"/"+key] = true
+		j.changes = append(j.changes, journalChange{kind: kind, key: key})
+	}
+}
+
+// record writes the operation to the journal. Operations that changed
+// nothing are left out, and a new operation drops everything that was
+// undone, since it can no longer be redone.
+func (j *journal) record() error {
+	var changes []journalChange
+	for _, change := range j.changes {
+		after, err := readImageTx(j.tx, change.kind, change.key)
+		if err != nil {
+			return err
+		}
+		if after == change.before {
+			continue
+		}
+		change.after = after
+		changes = append(changes, change)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	for _, q := range []string{
+		`DELETE FROM operation_change WHERE operation_id IN (SELECT id FROM operation WHERE undone_at IS NOT NULL)`,
+		`DELETE FROM operation WHERE undone_at IS NOT NULL`,
+	} {
+		if _, err := j.tx.Exec(q); err != nil {
+			return err
+		}
+	}
+
+	result, err := j.tx.Exec(
+		`INSERT INTO operation (name, created_at) VALUES (?, ?)`,
+		j.name,
+		time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for seq, change := range changes {
+		if _, err := j.tx.Exec(
+			`INSERT INTO operation_change (operation_id, seq, kind, item_key, before_image, after_image)
+			 VALUES (?, ?, ?, ?, ?, ?)`,
+			id,
+			seq,
+			change.kind,
+			change.key,
+			change.before,
+			change.after,
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, q := range []string{
+		`DELETE FROM operation WHERE id <= ? - ?`,
+		`DELETE FROM operation_change WHERE operation_id <= ? - ?`,
+	} {
+		if _, err := j.tx.Exec(q, id, journalLimit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readImageTx returns the JSON image of a row, or null when it does not
+// exist. Times are stored in UTC so that equal rows give equal images.
+func readImageTx(tx *sql.Tx, kind imageKind, key string) (sql.NullString, error) {
+	var image any
+	switch kind {
+	case imageLog:
+		record, err := getLogRecord(tx, key)
+		if errors.Is(err, ErrLogNotFound) {
+			return sql.NullString{}, nil
+		}
+		if err != nil {
+			return sql.NullString{}, err
+		}
+		image = record.utc()
+	case imageActive:
+		records, err := getActiveRecords(tx)
+		if err != nil {
+			return sql.NullString{}, err
+		}
+		for _, record := range records {
+			if record.Name == key {
+				image = record.utc()
+			}
+		}
+	case imageTrash:
+		items, err := getTrash(tx, `WHERE id = ?`, key)
+		if err != nil {
+			return sql.NullString{}, err
+		}
+		if len(items) > 0 {
+			image = items[0].utc()
+		}
+	default:
+		return sql.NullString{}, fmt.Errorf("unknown journal kind %q", kind)
+	}
+	if image == nil {
+		return sql.NullString{}, nil
+	}
+
+	data, err := json.Marshal(image)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// writeImagesTx sets every row in changes to its before or after image.
+// All rows are removed first so that re-inserted rows cannot collide.
+func writeImagesTx(tx *sql.Tx, changes []journalChange, after bool) error {
+	for _, change := range changes {
+		var err error
+		switch change.kind {
+		case imageLog:
+			err = deleteTaskLogsTx(tx, []string{change.key})
+		case imageActive:
+			err = deleteActiveTaskTx(tx, change.key)
+		case imageTrash:
+			_, err = tx.Exec(`DELETE FROM trash WHERE id = ?`, change.key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, change := range changes {
+		image := change.before
+		if after {
+			image = change.after
+		}
+		if !image.Valid {
+			continue
+		}
+
+		var err error
+		switch change.kind {
+		case imageLog:
+			var record LogRecord
+			if err = json.Unmarshal([]byte(image.String), &record); err == nil {
+				_, err = insertTaskLogTx(tx, record.newTaskLog())
+			}
+		case imageActive:
+			var record ActiveRecord
+			if err = json.Unmarshal([]byte(image.String), &record); err == nil {
+				err = insertActiveRecordTx(tx, record)
+			}
+		case imageTrash:
+			var item TrashItem
+			if err = json.Unmarshal([]byte(image.String), &item); err == nil {
+				_, err = insertTrashTx(tx, item)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", change.kind, change.key, err)
+		}
+	}
+	return nil
+}
+
+// Undo reverts the most recent operation that has not been undone. It fails
+// with ErrJournalConflict if the rows it touched have changed since.
+func (s *Store) Undo() (Operation, error) {
+	return s.replay(
+		`SELECT id FROM operation WHERE undone_at IS NULL ORDER BY id DESC LIMIT 1`,
+		false,
+	)
+}
+
+// Redo re-applies the operation undone last.
+func (s *Store) Redo() (Operation, error) {
+	return s.replay(
+		`SELECT id FROM operation WHERE undone_at IS NOT NULL ORDER BY id ASC LIMIT 1`,
+		true,
+	)
+}
+
+func (s *Store) replay(pick string, redo bool) (Operation, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Operation{}, err
+	}
+
+	var id int64
+	if err := tx.QueryRow(pick).Scan(&id); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			if redo {
+				return Operation{}, ErrNothingToRedo
+			}
+			return Operation{}, ErrNothingToUndo
+		}
+		return Operation{}, err
+	}
+
+	changes, err := getOperationChangesTx(tx, id)
+	if err != nil {
+		tx.Rollback()
+		return Operation{}, err
+	}
+	for _, change := range changes {
+		expected := change.after
+		if redo {
+			expected = change.before
+		}
+		current, err := readImageTx(tx, change.kind, change.key)
+		if err != nil {
+			tx.Rollback()
+			return Operation{}, err
+		}
+		if current != expected {
+			tx.Rollback()
+			return Operation{}, fmt.Errorf("%w: %s %s", ErrJournalConflict, change.kind, change.key)
+		}
+	}
+
+	if err := writeImagesTx(tx, changes, redo); err != nil {
+		tx.Rollback()
+		return Operation{}, err
+	}
+	if err := recordReplayRevisionsTx(tx, changes, redo); err != nil {
+		tx.Rollback()
+		return Operation{}, err
+	}
+
+	var undoneAt sql.NullTime
+	if !redo {
+		undoneAt = sql.NullTime{Time: time.Now(), Valid: true}
+	}
+	if _, err := tx.Exec(`UPDATE operation SET undone_at = ? WHERE id = ?`, undoneAt, id); err != nil {
+		tx.Rollback()
+		return Operation{}, err
+	}
+
+	ops, err := getOperations(tx, `WHERE operation.id = ?`, id)
+	if err != nil {
+		tx.Rollback()
+		return Operation{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Operation{}, err
+	}
+	return ops[0], nil
+}
+
+// recordReplayRevisionsTx adds the field changes made by an undo or redo to
+// the revision history of each log that existed on both sides.
+func recordReplayRevisionsTx(tx *sql.Tx, changes []journalChange, redo bool) error {
+	reason := "undo"
+	if redo {
+		reason = "redo"
+	}
+	now := time.Now()
+	for _, change := range changes {
+		if change.kind != imageLog || !change.before.Valid || !change.after.Valid {
+			continue
+		}
+		var from, to LogRecord
+		if err := json.Unmarshal([]byte(change.before.String), &from); err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(change.after.String), &to); err != nil {
+			return err
+		}
+		if !redo {
+			from, to = to, from
+		}
+		if err := recordRevisionsTx(tx, from, to, now, reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getOperationChangesTx(tx *sql.Tx, id int64) ([]journalChange, error) {
+	rows, err := tx.Query(
+		`SELECT kind, item_key, before_image, after_image FROM operation_change
+		 WHERE operation_id = ? ORDER BY seq ASC`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []journalChange
+	for rows.Next() {
+		var change journalChange
+		if err := rows.Scan(&change.kind, &change.key, &change.before, &change.after); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+// GetOperations returns the most recent operations in the journal, newest
+// first, including ones that have been undone.
+func (s *Store) GetOperations(limit int) ([]Operation, error) {
+	return getOperations(s.db, `ORDER BY operation.id DESC LIMIT ?`, limit)
+}
+
+func getOperations(q querier, clause string, args ...any) ([]Operation, error) {
+	rows, err := q.Query(
+		`SELECT operation.id, operation.name, operation.created_at, operation.undone_at,
+		        (SELECT COUNT(*) FROM operation_change WHERE operation_id = operation.id)
+		 FROM operation `+clause,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ops []Operation
+	for rows.Next() {
+		var (
+			op       Operation
+			undoneAt sql.NullTime
+		)
+		if err := rows.Scan(&op.ID, &op.Name, &op.CreatedAt, &undoneAt, &op.Changes); err != nil {
+			return nil, err
+		}
+		if undoneAt.Valid {
+			op.UndoneAt = &undoneAt.Time
+		}
+		ops = append(ops, op)
+	}
+	return ops, rows.Err()
+}
+
+func (r LogRecord) utc() LogRecord {
+	r.StartTime = r.StartTime.UTC()
+	r.EndTime = r.EndTime.UTC()
+	r.Pauses = utcPauses(r.Pauses)
+	return r
+}
+
+func (r ActiveRecord) utc() ActiveRecord {
+	r.StartTime = r.StartTime.UTC()
+	r.Pauses = utcPauses(r.Pauses)
+	return r
+}
+
+func (item TrashItem) utc() TrashItem {
+	item.DeletedAt = item.DeletedAt.UTC()
+	if item.Log != nil {
+		record := item.Log.utc()
+		item.Log = &record
+	}
+	if item.Active != nil {
+		record := item.Active.utc()
+		item.Active = &record
+	}
+	return item
+}
+
+func utcPauses(pauses []PauseInterval) []PauseInterval {
+	out := make([]PauseInterval, 0, len(pauses))
+	for _, pause := range pauses {
+		pause.StartTime = pause.StartTime.UTC()
+		if pause.EndTime != nil {
+			end := pause.EndTime.UTC()
+			pause.EndTime = &end
+		}
+		out = append(out, pause)
+	}
+	return out
+}
@@ -70,6 +70,8 @@ func logIDLookupQuery(table string) (string, error) {
 		return `SELECT id FROM task_log WHERE id = ?`, nil
 	case "task_log_new":
 		return `SELECT id FROM task_log_new WHERE id = ?`, nil
+	case "trash":
+		return `SELECT id FROM trash WHERE id = ?`, nil
 	default:
 		return "", fmt.Errorf("unsupported log id table: %s", table)
 	}
@@ -1,19 +1,54 @@
 package store
 
-import "time"
+import (
+	"database/sql"
+	"errors"
+)
 
-func (s *Store) GetTaskLogs(since *time.Time) ([]TaskLogEntry, error) {
-	query := `SELECT id, task_name, start_time, end_time, duration_seconds FROM task_log`
-	args := []any{}
+const taskLogColumns = `task_log.id, task_log.task_name, task_log.start_time, task_log.end_time,
+	task_log.duration_seconds, task_log.paused_seconds, COALESCE(p.name, ''), COALESCE(c.name, ''),
+	COALESCE((
+		SELECT GROUP_CONCAT(tag.name) FROM task_log_tag
+		JOIN tag ON tag.id = task_log_tag.tag_id
+		WHERE task_log_tag.log_id = task_log.id
+	), '')`
 
-	if since != nil {
-		query += ` WHERE end_time >= ?`
-		args = append(args, *since)
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTaskLogEntry reads the taskLogColumns, then any extra columns into
+// extra.
+func scanTaskLogEntry(row rowScanner, extra ...any) (TaskLogEntry, error) {
+	var (
+		entry TaskLogEntry
+		tags  string
+	)
+	dest := []any{
+		&entry.ID,
+		&entry.TaskName,
+		&entry.StartTime,
+		&entry.EndTime,
+		&entry.DurationSeconds,
+		&entry.PausedSeconds,
+		&entry.ProjectName,
+		&entry.ClientName,
+		&tags,
 	}
+	err := row.Scan(append(dest, extra...)...)
+	entry.Tags = splitTags(tags)
+	return entry, err
+}
 
-	query += ` ORDER BY end_time DESC`
+func (s *Store) GetTaskLogs(filter LogFilter) ([]TaskLogEntry, error) {
+	return getTaskLogs(s.db, filter)
+}
 
-	rows, err := s.db.Query(query, args...)
+func getTaskLogs(q querier, filter LogFilter) ([]TaskLogEntry, error) {
+	where, args := filter.where()
+	query := `SELECT ` + taskLogColumns + ` FROM task_log` + projectJoins + where + ` ORDER BY task_log.end_time DESC`
+
+	rows, err := q.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -21,14 +56,8 @@ func (s *Store) GetTaskLogs(since *time.Time) ([]TaskLogEntry, error) {
 
 	var logs []TaskLogEntry
 	for rows.Next() {
-		var entry TaskLogEntry
-		if err := rows.Scan(
-			&entry.ID,
-			&entry.TaskName,
-			&entry.StartTime,
-			&entry.EndTime,
-			&entry.DurationSeconds,
-		); err != nil {
+		entry, err := scanTaskLogEntry(rows)
+		if err != nil {
 			return nil, err
 		}
 		logs = append(logs, entry)
@@ -40,16 +69,40 @@ func (s *Store) GetTaskLogs(since *time.Time) ([]TaskLogEntry, error) {
 	return logs, nil
 }
 
-func (s *Store) GetTaskLogGroups(since *time.Time) ([]TaskLogGroup, error) {
-	query := `SELECT task_name, SUM(duration_seconds) AS total_seconds, COUNT(*) AS session_count FROM task_log`
-	args := []any{}
+// EachTaskLog calls fn for each log matching filter, oldest start first. Rows
+// are read as fn consumes them, so large ranges are never held in memory.
+// An error from fn stops the scan and is returned.
+func (s *Store) EachTaskLog(filter LogFilter, fn func(TaskLogEntry) error) error {
+	where, args := filter.where()
+	query := `SELECT ` + taskLogColumns + ` FROM task_log` + projectJoins + where +
+		` ORDER BY julianday(task_log.start_time) ASC, task_log.id ASC`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
-	if since != nil {
-		query += ` WHERE end_time >= ?`
-		args = append(args, *since)
+	for rows.Next() {
+		entry, err := scanTaskLogEntry(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
 	}
+	return rows.Err()
+}
 
-	query += ` GROUP BY task_name ORDER BY total_seconds DESC, task_name ASC`
+func (s *Store) GetTaskLogGroups(filter LogFilter, by GroupBy) ([]TaskLogGroup, error) {
+	columns, joins, groupBy := groupColumns(by)
+	seconds, args := filter.Range.workedSeconds()
+	where, whereArgs := filter.where()
+	args = append(args, whereArgs...)
+	query := `SELECT ` + columns + `, SUM(` + seconds + `) AS total_seconds, COUNT(*) AS session_count
+		FROM task_log` + projectJoins + joins + where +
+		` GROUP BY ` + groupBy + ` ORDER BY total_seconds DESC, 1 ASC, 2 ASC, 3 ASC, 4 ASC`
 
 	rows, err := s.db.Query(query, args...)
 	if err != nil {
@@ -60,7 +113,14 @@ func (s *Store) GetTaskLogGroups(since *time.Time) ([]TaskLogGroup, error) {
 	var groups []TaskLogGroup
 	for rows.Next() {
 		var group TaskLogGroup
-		if err := rows.Scan(&group.TaskName, &group.DurationSeconds, &group.SessionCount); err != nil {
+		if err := rows.Scan(
+			&group.TaskName,
+			&group.ProjectName,
+			&group.ClientName,
+			&group.Tag,
+			&group.DurationSeconds,
+			&group.SessionCount,
+		); err != nil {
 			return nil, err
 		}
 		groups = append(groups, group)
@@ -71,3 +131,50 @@ func (s *Store) GetTaskLogGroups(since *time.Time) ([]TaskLogGroup, error) {
 
 	return groups, nil
 }
+
+func (s *Store) GetTaskLog(id string) (TaskLogEntry, error) {
+	return getTaskLog(s.db, id)
+}
+
+func getTaskLog(q querier, id string) (TaskLogEntry, error) {
+	entry, err := scanTaskLogEntry(q.QueryRow(
+		`SELECT `+taskLogColumns+` FROM task_log`+projectJoins+` WHERE task_log.id = ?`,
+		id,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return TaskLogEntry{}, ErrLogNotFound
+	}
+	return entry, err
+}
+
+// GetRecentTaskLogs returns the most recently ended logs, newest first.
+func (s *Store) GetRecentTaskLogs(limit int) ([]TaskLogEntry, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	rows, err := s.db.Query(
+		`SELECT `+taskLogColumns+` FROM task_log`+projectJoins+`
+		 ORDER BY julianday(task_log.end_time) DESC
+		 LIMIT ?`,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []TaskLogEntry
+	for rows.Next() {
+		entry, err := scanTaskLogEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
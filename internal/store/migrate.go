@@ -1,27 +1,427 @@
 package store
 
-import "database/sql"
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
 
-func migrate(db *sql.DB) error {
-	queries := []string{
-		`CREATE TABLE IF NOT EXISTS active_task (
-			task_name TEXT PRIMARY KEY,
-			start_time DATETIME NOT NULL
+type migration struct {
+	version int
+	name    string
+	up      func(tx *sql.Tx) error
+}
+
+// migrations is the ordered list of schema changes. Append new steps with the
+// next version number; never edit or reorder a step that has shipped.
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "create base tables",
+		up: execAll(
+			`CREATE TABLE IF NOT EXISTS active_task (
+				task_name TEXT PRIMARY KEY,
+				start_time DATETIME NOT NULL
+			);`,
+			`CREATE TABLE IF NOT EXISTS task_log (
+				id TEXT PRIMARY KEY,
+				task_name TEXT NOT NULL,
+				start_time DATETIME NOT NULL,
+				end_time DATETIME NOT NULL,
+				duration_seconds INTEGER NOT NULL
+			);`,
+		),
+	},
+	{
+		version: 2,
+		name:    "convert legacy integer log ids",
+		up:      migrateTextLogIDs,
+	},
+	{
+		version: 3,
+		name:    "add projects and clients",
+		up: execAll(
+			`CREATE TABLE client (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL UNIQUE
+			);`,
+			`CREATE TABLE project (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL,
+				client_id INTEGER REFERENCES client(id)
+			);`,
+			`CREATE UNIQUE INDEX project_client_name ON project (COALESCE(client_id, 0), name);`,
+			`ALTER TABLE task_log ADD COLUMN project_id INTEGER REFERENCES project(id);`,
+			`ALTER TABLE active_task ADD COLUMN project_id INTEGER REFERENCES project(id);`,
+		),
+	},
+	{
+		version: 4,
+		name:    "add tags",
+		up: execAll(
+			`CREATE TABLE tag (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL UNIQUE
+			);`,
+			`CREATE TABLE task_log_tag (
+				log_id TEXT NOT NULL,
+				tag_id INTEGER NOT NULL REFERENCES tag(id),
+				PRIMARY KEY (log_id, tag_id)
+			);`,
+			`CREATE TABLE active_task_tag (
+				task_name TEXT NOT NULL,
+				tag_id INTEGER NOT NULL REFERENCES tag(id),
+				PRIMARY KEY (task_name, tag_id)
+			);`,
+		),
+	},
+	{
+		version: 5,
+		name:    "add pause intervals",
+		up: execAll(
+			`CREATE TABLE active_task_pause (
+				task_name TEXT NOT NULL,
+				start_time DATETIME NOT NULL,
+				end_time DATETIME
+			);`,
+			`CREATE TABLE task_log_pause (
+				log_id TEXT NOT NULL,
+				start_time DATETIME NOT NULL,
+				end_time DATETIME NOT NULL
+			);`,
+			`ALTER TABLE task_log ADD COLUMN paused_seconds INTEGER NOT NULL DEFAULT 0;`,
+		),
+	},
+	{
+		version: 6,
+		name:    "add settings",
+		up: execAll(
+			`CREATE TABLE setting (
+				key TEXT PRIMARY KEY,
+				value TEXT NOT NULL
+			);`,
+		),
+	},
+	{
+		version: 7,
+		name:    "add import hashes",
+		up: execAll(
+			`ALTER TABLE task_log ADD COLUMN import_hash TEXT;`,
+			`CREATE UNIQUE INDEX task_log_import_hash ON task_log (import_hash) WHERE import_hash IS NOT NULL;`,
+		),
+	},
+	{
+		version: 8,
+		name:    "add trash",
+		up: execAll(
+			`CREATE TABLE trash (
+				id TEXT PRIMARY KEY,
+				kind TEXT NOT NULL,
+				item_id TEXT NOT NULL,
+				deleted_at DATETIME NOT NULL,
+				payload TEXT NOT NULL
+			);`,
+		),
+	},
+	{
+		version: 9,
+		name:    "add operation journal",
+		up: execAll(
+			`CREATE TABLE operation (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL,
+				created_at DATETIME NOT NULL,
+				undone_at DATETIME
+			);`,
+			`CREATE TABLE operation_change (
+				operation_id INTEGER NOT NULL,
+				seq INTEGER NOT NULL,
+				kind TEXT NOT NULL,
+				item_key TEXT NOT NULL,
+				before_image TEXT,
+				after_image TEXT,
+				PRIMARY KEY (operation_id, seq)
+			);`,
+		),
+	},
+	{
+		version: 10,
+		name:    "add log revisions",
+		up: execAll(
+			`CREATE TABLE task_log_revision (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				log_id TEXT NOT NULL,
+				field TEXT NOT NULL,
+				old_value TEXT NOT NULL,
+				new_value TEXT NOT NULL,
+				changed_at DATETIME NOT NULL,
+				reason TEXT NOT NULL DEFAULT ''
+			);`,
+			`CREATE INDEX task_log_revision_log_id ON task_log_revision(log_id);`,
+		),
+	},
+}
+
+func SchemaVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+func execAll(queries ...string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, q := range queries {
+			if _, err := tx.Exec(q); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func migrate(db *sql.DB) ([]MigrationStatus, error) {
+	if err := ensureSchemaVersionTable(db); err != nil {
+		return nil, err
+	}
+
+	current, err := currentSchemaVersion(db)
+	if err != nil {
+		return nil, err
+	}
+	if current > SchemaVersion() {
+		return nil, fmt.Errorf(
+			"%w (database version %d, supported up to %d)",
+			ErrSchemaTooNew,
+			current,
+			SchemaVersion(),
+		)
+	}
+
+	var applied []MigrationStatus
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return applied, err
+		}
+		if err := m.up(tx); err != nil {
+			tx.Rollback()
+			return applied, fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+		}
+
+		appliedAt := time.Now()
+		if _, err := tx.Exec(
+			`INSERT INTO schema_version (version, name, applied_at) VALUES (?, ?, ?)`,
+			m.version,
+			m.name,
+			appliedAt,
+		); err != nil {
+			tx.Rollback()
+			return applied, err
+		}
+		if err := tx.Commit(); err != nil {
+			return applied, err
+		}
+
+		applied = append(applied, MigrationStatus{
+			Version:   m.version,
+			Name:      m.name,
+			AppliedAt: &appliedAt,
+		})
+	}
+
+	return applied, nil
+}
+
+func ensureSchemaVersionTable(db *sql.DB) error {
+	_, err := db.Exec(
+		`CREATE TABLE IF NOT EXISTS schema_version (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at DATETIME NOT NULL
 		);`,
-		`CREATE TABLE IF NOT EXISTS task_log (
+	)
+	return err
+}
+
+func currentSchemaVersion(db *sql.DB) (int, error) {
+	var version int
+	err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&version)
+	return version, err
+}
+
+func schemaVersionTableExists(db *sql.DB) (bool, error) {
+	var name string
+	err := db.QueryRow(
+		`SELECT name FROM sqlite_master WHERE type = 'table' AND name = 'schema_version'`,
+	).Scan(&name)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *Store) Migrate() ([]MigrationStatus, error) {
+	return migrate(s.db)
+}
+
+// MigrationStatus lists known migrations with their applied time, followed
+// by any versions recorded in the database that this binary does not know.
+// It does not modify the database.
+func (s *Store) MigrationStatus() ([]MigrationStatus, error) {
+	appliedAt := map[int]time.Time{}
+	names := map[int]string{}
+
+	exists, err := schemaVersionTableExists(s.db)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		rows, err := s.db.Query(`SELECT version, name, applied_at FROM schema_version ORDER BY version ASC`)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var (
+				version int
+				name    string
+				at      time.Time
+			)
+			if err := rows.Scan(&version, &name, &at); err != nil {
+				return nil, err
+			}
+			appliedAt[version] = at
+			names[version] = name
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	var statuses []MigrationStatus
+	for _, m := range migrations {
+		status := MigrationStatus{Version: m.version, Name: m.name}
+		if at, ok := appliedAt[m.version]; ok {
+			status.AppliedAt = &at
+			delete(appliedAt, m.version)
+		}
+		statuses = append(statuses, status)
+	}
+	for version, at := range appliedAt {
+		statuses = append(statuses, MigrationStatus{
+			Version:   version,
+			Name:      names[version],
+			AppliedAt: &at,
+			Unknown:   true,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+// migrateTextLogIDs rebuilds task_log for databases created before log ids
+// became random 8-character strings. Newer databases are left untouched.
+func migrateTextLogIDs(tx *sql.Tx) error {
+	idType, err := columnType(tx, "task_log", "id")
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(idType, "TEXT") {
+		return nil
+	}
+
+	if _, err := tx.Exec(
+		`CREATE TABLE task_log_new (
 			id TEXT PRIMARY KEY,
 			task_name TEXT NOT NULL,
 			start_time DATETIME NOT NULL,
 			end_time DATETIME NOT NULL,
 			duration_seconds INTEGER NOT NULL
 		);`,
+	); err != nil {
+		return err
 	}
 
-	for _, q := range queries {
-		if _, err := db.Exec(q); err != nil {
+	rows, err := tx.Query(`SELECT task_name, start_time, end_time, duration_seconds FROM task_log`)
+	if err != nil {
+		return err
+	}
+	var entries []TaskLogEntry
+	for rows.Next() {
+		var entry TaskLogEntry
+		if err := rows.Scan(&entry.TaskName, &entry.StartTime, &entry.EndTime, &entry.DurationSeconds); err != nil {
+			rows.Close()
 			return err
 		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	for _, entry := range entries {
+		id, err := generateUniqueLogIDTx(tx, "task_log_new")
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO task_log_new (id, task_name, start_time, end_time, duration_seconds)
+			 VALUES (?, ?, ?, ?, ?)`,
+			id,
+			entry.TaskName,
+			entry.StartTime,
+			entry.EndTime,
+			entry.DurationSeconds,
+		); err != nil {
+			return err
+		}
+	}
+
+	return execAll(
+		`DROP TABLE task_log;`,
+		`ALTER TABLE task_log_new RENAME TO task_log;`,
+	)(tx)
+}
+
+func columnType(tx *sql.Tx, table string, column string) (string, error) {
+	rows, err := tx.Query(fmt.Sprintf(`PRAGMA table_info(%s)`, table))
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return "", err
+		}
+		if name == column {
+			return colType, nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
 	}
 
-	return nil
+	return "", fmt.Errorf("column %s.%s not found", table, column)
 }
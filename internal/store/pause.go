@@ -0,0 +1,237 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// PauseTask opens a pause interval on an active task. A zero at means now.
+func (s *Store) PauseTask(task string, at time.Time) error {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	startTime, err := activeStartTimeTx(tx, task)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	j := newJournal(tx, fmt.Sprintf("pause %q", task))
+	if err := j.touch(imageActive, task); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	pauses, err := getActivePauses(tx, task)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if len(pauses) > 0 {
+		last := pauses[len(pauses)-1]
+		if last.EndTime == nil {
+			tx.Rollback()
+			return ErrTaskPaused
+		}
+		if at.Before(*last.EndTime) {
+			tx.Rollback()
+			return ErrInvalidTimeRange
+		}
+	}
+	if at.Before(startTime) {
+		tx.Rollback()
+		return ErrInvalidTimeRange
+	}
+
+	if _, err := tx.Exec(
+		`INSERT INTO active_task_pause (task_name, start_time) VALUES (?, ?)`,
+		task,
+		at,
+	); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ResumeTask closes the open pause on an active task and returns its length.
+func (s *Store) ResumeTask(task string, at time.Time) (time.Duration, error) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := activeStartTimeTx(tx, task); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	var pausedAt time.Time
+	err = tx.QueryRow(
+		`SELECT start_time FROM active_task_pause WHERE task_name = ? AND end_time IS NULL`,
+		task,
+	).Scan(&pausedAt)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrTaskNotPaused
+		}
+		return 0, err
+	}
+	if at.Before(pausedAt) {
+		tx.Rollback()
+		return 0, ErrInvalidTimeRange
+	}
+	j := newJournal(tx, fmt.Sprintf("resume %q", task))
+	if err := j.touch(imageActive, task); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if _, err := tx.Exec(
+		`UPDATE active_task_pause SET end_time = ? WHERE task_name = ? AND end_time IS NULL`,
+		at,
+		task,
+	); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return at.Sub(pausedAt), nil
+}
+
+func activeStartTimeTx(tx *sql.Tx, task string) (time.Time, error) {
+	var startTime time.Time
+	err := tx.QueryRow(`SELECT start_time FROM active_task WHERE task_name = ?`, task).Scan(&startTime)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, ErrTaskNotActive
+	}
+	return startTime, err
+}
+
+func getActivePauses(q querier, task string) ([]PauseInterval, error) {
+	return scanPauses(q.Query(
+		`SELECT start_time, end_time FROM active_task_pause WHERE task_name = ? ORDER BY start_time ASC`,
+		task,
+	))
+}
+
+// GetTaskLogPauses returns the pauses recorded for a log, oldest first.
+func (s *Store) GetTaskLogPauses(id string) ([]PauseInterval, error) {
+	return getLogPauses(s.db, id)
+}
+
+func getLogPauses(q querier, logID string) ([]PauseInterval, error) {
+	return scanPauses(q.Query(
+		`SELECT start_time, end_time FROM task_log_pause WHERE log_id = ? ORDER BY start_time ASC`,
+		logID,
+	))
+}
+
+func scanPauses(rows *sql.Rows, err error) ([]PauseInterval, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pauses []PauseInterval
+	for rows.Next() {
+		var (
+			pause   PauseInterval
+			endTime sql.NullTime
+		)
+		if err := rows.Scan(&pause.StartTime, &endTime); err != nil {
+			return nil, err
+		}
+		if endTime.Valid {
+			pause.EndTime = &endTime.Time
+		}
+		pauses = append(pauses, pause)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return pauses, nil
+}
+
+// clip returns the part of the pause inside [start, end]. An open pause runs
+// until end. ok is false when nothing of the pause is left.
+func (p PauseInterval) clip(start time.Time, end time.Time) (time.Time, time.Time, bool) {
+	pauseStart := p.StartTime
+	pauseEnd := end
+	if p.EndTime != nil {
+		pauseEnd = *p.EndTime
+	}
+	if pauseStart.Before(start) {
+		pauseStart = start
+	}
+	if pauseEnd.After(end) {
+		pauseEnd = end
+	}
+	return pauseStart, pauseEnd, pauseEnd.After(pauseStart)
+}
+
+func pausedWithin(pauses []PauseInterval, start time.Time, end time.Time) time.Duration {
+	var total time.Duration
+	for _, pause := range pauses {
+		if pauseStart, pauseEnd, ok := pause.clip(start, end); ok {
+			total += pauseEnd.Sub(pauseStart)
+		}
+	}
+	return total
+}
+
+// replaceLogPausesTx rewrites the pause intervals of a log, clipped to the
+// log's [start, end], and returns the paused time that remains.
+func replaceLogPausesTx(tx *sql.Tx, logID string, pauses []PauseInterval, start time.Time, end time.Time) (time.Duration, error) {
+	if _, err := tx.Exec(`DELETE FROM task_log_pause WHERE log_id = ?`, logID); err != nil {
+		return 0, err
+	}
+
+	var total time.Duration
+	for _, pause := range pauses {
+		pauseStart, pauseEnd, ok := pause.clip(start, end)
+		if !ok {
+			continue
+		}
+
+		if _, err := tx.Exec(
+			`INSERT INTO task_log_pause (log_id, start_time, end_time) VALUES (?, ?, ?)`,
+			logID,
+			pauseStart,
+			pauseEnd,
+		); err != nil {
+			return 0, err
+		}
+		total += pauseEnd.Sub(pauseStart)
+	}
+	return total, nil
+}
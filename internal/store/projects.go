@@ -0,0 +1,117 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// ProjectRef names a project, optionally scoped to a client, as written on
+// the command line: "client/project" or just "project".
+type ProjectRef struct {
+	Client string
+	Name   string
+}
+
+func ParseProjectRef(value string) (ProjectRef, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ProjectRef{}, nil
+	}
+
+	client, name, found := strings.Cut(value, "/")
+	if !found {
+		return ProjectRef{Name: value}, nil
+	}
+
+	client = strings.TrimSpace(client)
+	name = strings.TrimSpace(name)
+	if client == "" || name == "" || strings.Contains(name, "/") {
+		return ProjectRef{}, fmt.Errorf("invalid project %q. use 'project' or 'client/project'", value)
+	}
+	return ProjectRef{Client: client, Name: name}, nil
+}
+
+func (r ProjectRef) IsZero() bool {
+	return r.Name == ""
+}
+
+func (r ProjectRef) String() string {
+	if r.Client == "" {
+		return r.Name
+	}
+	return r.Client + "/" + r.Name
+}
+
+func resolveProjectTx(tx *sql.Tx, ref ProjectRef) (sql.NullInt64, error) {
+	if ref.IsZero() {
+		return sql.NullInt64{}, nil
+	}
+
+	var clientID sql.NullInt64
+	if ref.Client != "" {
+		if _, err := tx.Exec(`INSERT INTO client (name) VALUES (?) ON CONFLICT(name) DO NOTHING`, ref.Client); err != nil {
+			return sql.NullInt64{}, err
+		}
+		if err := tx.QueryRow(`SELECT id FROM client WHERE name = ?`, ref.Client).Scan(&clientID); err != nil {
+			return sql.NullInt64{}, err
+		}
+	}
+
+	var projectID int64
+	err := tx.QueryRow(
+		`SELECT id FROM project WHERE name = ? AND client_id IS ?`,
+		ref.Name,
+		clientID,
+	).Scan(&projectID)
+	if err == nil {
+		return sql.NullInt64{Int64: projectID, Valid: true}, nil
+	}
+	if err != sql.ErrNoRows {
+		return sql.NullInt64{}, err
+	}
+
+	result, err := tx.Exec(`INSERT INTO project (name, client_id) VALUES (?, ?)`, ref.Name, clientID)
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+	projectID, err = result.LastInsertId()
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+	return sql.NullInt64{Int64: projectID, Valid: true}, nil
+}
+
+func (s *Store) GetProjects() ([]Project, error) {
+	rows, err := s.db.Query(
+		`SELECT p.name, COALESCE(c.name, ''),
+			(SELECT COUNT(*) FROM task_log WHERE task_log.project_id = p.id),
+			(SELECT COALESCE(SUM(duration_seconds), 0) FROM task_log WHERE task_log.project_id = p.id)
+		 FROM project p
+		 LEFT JOIN client c ON c.id = p.client_id
+		 ORDER BY COALESCE(c.name, ''), p.name`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []Project
+	for rows.Next() {
+		var project Project
+		if err := rows.Scan(
+			&project.Name,
+			&project.ClientName,
+			&project.SessionCount,
+			&project.DurationSeconds,
+		); err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
@@ -0,0 +1,170 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// getLogRecords returns full images of the logs matching filter.
+func getLogRecords(q querier, filter LogFilter) ([]LogRecord, error) {
+	logs, err := getTaskLogs(q, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]LogRecord, 0, len(logs))
+	for _, entry := range logs {
+		record, err := logRecordFor(q, entry)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func getLogRecord(q querier, id string) (LogRecord, error) {
+	entry, err := getTaskLog(q, id)
+	if err != nil {
+		return LogRecord{}, err
+	}
+	return logRecordFor(q, entry)
+}
+
+func logRecordFor(q querier, entry TaskLogEntry) (LogRecord, error) {
+	pauses, err := getLogPauses(q, entry.ID)
+	if err != nil {
+		return LogRecord{}, err
+	}
+	if pauses == nil {
+		pauses = []PauseInterval{}
+	}
+
+	var importHash sql.NullString
+	if err := q.QueryRow(`SELECT import_hash FROM task_log WHERE id = ?`, entry.ID).Scan(&importHash); err != nil {
+		return LogRecord{}, err
+	}
+
+	return LogRecord{
+		ID:         entry.ID,
+		TaskName:   entry.TaskName,
+		StartTime:  entry.StartTime,
+		EndTime:    entry.EndTime,
+		Project:    entry.ProjectName,
+		Client:     entry.ClientName,
+		Tags:       nonNilTags(entry.Tags),
+		Pauses:     pauses,
+		ImportHash: importHash.String,
+	}, nil
+}
+
+func (r LogRecord) newTaskLog() NewTaskLog {
+	return NewTaskLog{
+		ID:         r.ID,
+		TaskName:   r.TaskName,
+		StartTime:  r.StartTime,
+		EndTime:    r.EndTime,
+		Project:    ProjectRef{Client: r.Client, Name: r.Project},
+		Tags:       r.Tags,
+		Pauses:     r.Pauses,
+		ImportHash: r.ImportHash,
+	}
+}
+
+func (r LogRecord) validate() error {
+	if !IsValidLogID(r.ID) {
+		return fmt.Errorf("%w: %q", ErrInvalidLogID, r.ID)
+	}
+	if err := validateTaskLog(r.TaskName, r.StartTime, r.EndTime); err != nil {
+		return err
+	}
+	if r.Project == "" && r.Client != "" {
+		return fmt.Errorf("client %q has no project", r.Client)
+	}
+	for _, pause := range r.Pauses {
+		if pause.EndTime == nil || pause.StartTime.Before(r.StartTime) || pause.EndTime.Before(pause.StartTime) || pause.EndTime.After(r.EndTime) {
+			return ErrInvalidTimeRange
+		}
+	}
+	_, err := NormalizeTags(r.Tags)
+	return err
+}
+
+func getActiveRecords(q querier) ([]ActiveRecord, error) {
+	tasks, err := getActiveTasks(q)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]ActiveRecord, 0, len(tasks))
+	for _, task := range tasks {
+		pauses, err := getActivePauses(q, task.Name)
+		if err != nil {
+			return nil, err
+		}
+		if pauses == nil {
+			pauses = []PauseInterval{}
+		}
+		records = append(records, ActiveRecord{
+			Name:      task.Name,
+			StartTime: task.StartTime,
+			Project:   task.ProjectName,
+			Client:    task.ClientName,
+			Tags:      nonNilTags(task.Tags),
+			Pauses:    pauses,
+		})
+	}
+	return records, nil
+}
+
+func (r ActiveRecord) validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return ErrEmptyTaskName
+	}
+	if r.Project == "" && r.Client != "" {
+		return fmt.Errorf("client %q has no project", r.Client)
+	}
+	for _, pause := range r.Pauses {
+		if pause.StartTime.Before(r.StartTime) || (pause.EndTime != nil && pause.EndTime.Before(pause.StartTime)) {
+			return ErrInvalidTimeRange
+		}
+	}
+	_, err := NormalizeTags(r.Tags)
+	return err
+}
+
+// insertActiveRecordTx recreates a running task, including its pauses.
+func insertActiveRecordTx(tx *sql.Tx, r ActiveRecord) error {
+	if err := startTaskTx(tx, r.Name, StartOptions{
+		Project:   ProjectRef{Client: r.Client, Name: r.Project},
+		Tags:      r.Tags,
+		StartTime: r.StartTime,
+	}); err != nil {
+		return err
+	}
+
+	for _, pause := range r.Pauses {
+		var endTime sql.NullTime
+		if pause.EndTime != nil {
+			endTime = sql.NullTime{Time: *pause.EndTime, Valid: true}
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO active_task_pause (task_name, start_time, end_time) VALUES (?, ?, ?)`,
+			r.Name,
+			pause.StartTime,
+			endTime,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nonNilTags keeps records from encoding an empty tag list as null.
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
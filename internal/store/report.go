@@ -0,0 +1,125 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ReportDays lists the calendar days a report over filter covers. An open
+// side of filter.Range is closed at the first or last matching log, so a
+// report over all time starts on the first day with data.
+func (s *Store) ReportDays(filter LogFilter) ([]TimeRange, error) {
+	span := filter.Range
+	where, args := filter.where()
+	if span.From == nil {
+		var first time.Time
+		err := s.db.QueryRow(
+			`SELECT task_log.start_time FROM task_log`+where+
+				` ORDER BY julianday(task_log.start_time) ASC LIMIT 1`,
+			args...,
+		).Scan(&first)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		first = first.Local()
+		span.From = &first
+	}
+	if span.To == nil {
+		var last time.Time
+		err := s.db.QueryRow(
+			`SELECT task_log.end_time FROM task_log`+where+
+				` ORDER BY julianday(task_log.end_time) DESC LIMIT 1`,
+			args...,
+		).Scan(&last)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		span.To = &last
+	}
+	return span.Days(), nil
+}
+
+// GetTimesheet totals worked time per group for each of days. Sessions are
+// clipped to each day, so one crossing midnight counts toward both.
+func (s *Store) GetTimesheet(filter LogFilter, by GroupBy, days []TimeRange) ([]TimesheetCell, error) {
+	var cells []TimesheetCell
+	for _, day := range days {
+		dayFilter := filter
+		dayFilter.Range = filter.Range.Intersect(day)
+		rows, _, err := s.GetTaskDurationSummary(dayFilter, by)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if row.DurationSeconds <= 0 {
+				continue
+			}
+			cells = append(cells, TimesheetCell{
+				Date:            day.From.Format("2006-01-02"),
+				TaskName:        row.TaskName,
+				ProjectName:     row.ProjectName,
+				ClientName:      row.ClientName,
+				Tag:             row.Tag,
+				DurationSeconds: row.DurationSeconds,
+			})
+		}
+	}
+	return cells, nil
+}
+
+// GetDailySessions lists the sessions on each of days in start order, with
+// DurationSeconds counting only the worked time on that day.
+func (s *Store) GetDailySessions(filter LogFilter, days []TimeRange) ([]DailySession, error) {
+	var sessions []DailySession
+	for _, day := range days {
+		dayFilter := filter
+		dayFilter.Range = filter.Range.Intersect(day)
+		seconds, args := dayFilter.Range.workedSeconds()
+		where, whereArgs := dayFilter.where()
+		args = append(args, whereArgs...)
+
+		rows, err := s.db.Query(
+			`SELECT `+taskLogColumns+`, `+seconds+` FROM task_log`+projectJoins+where+
+				` ORDER BY julianday(task_log.start_time) ASC, task_log.id ASC`,
+			args...,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var worked int
+			entry, err := scanTaskLogEntry(rows, &worked)
+			if err != nil {
+				rows.Close()
+				return nil, err
+			}
+			if worked <= 0 {
+				continue
+			}
+			sessions = append(sessions, DailySession{
+				Date:            day.From.Format("2006-01-02"),
+				ID:              entry.ID,
+				TaskName:        entry.TaskName,
+				StartTime:       entry.StartTime,
+				EndTime:         entry.EndTime,
+				DurationSeconds: worked,
+				ProjectName:     entry.ProjectName,
+				ClientName:      entry.ClientName,
+				Tags:            entry.Tags,
+			})
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sessions, nil
+}
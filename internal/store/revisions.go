@@ -0,0 +1,109 @@
+package store
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// Fields tracked in a log's revision history.
+const (
+	RevisionTask    = "task"
+	RevisionStart   = "start_time"
+	RevisionEnd     = "end_time"
+	RevisionProject = "project"
+	RevisionTags    = "tags"
+)
+
+// fixOverlapsReason starts the reason of revisions made by FixOverlaps. They
+// are automatic, so the edited filter leaves them out.
+const fixOverlapsReason = "fix overlaps"
+
+func isRevisionField(field string) bool {
+	switch field {
+	case RevisionTask, RevisionStart, RevisionEnd, RevisionProject, RevisionTags:
+		return true
+	}
+	return false
+}
+
+// recordRevisionsTx appends one revision per field that differs between
+// before and after. Times are stored as RFC3339.
+func recordRevisionsTx(tx *sql.Tx, before LogRecord, after LogRecord, at time.Time, reason string) error {
+	changes := []struct {
+		field string
+		old   string
+		new   string
+	}{
+		{RevisionTask, before.TaskName, after.TaskName},
+		{RevisionStart, before.StartTime.Format(time.RFC3339), after.StartTime.Format(time.RFC3339)},
+		{RevisionEnd, before.EndTime.Format(time.RFC3339), after.EndTime.Format(time.RFC3339)},
+		{
+			RevisionProject,
+			ProjectRef{Client: before.Client, Name: before.Project}.String(),
+			ProjectRef{Client: after.Client, Name: after.Project}.String(),
+		},
+		{RevisionTags, strings.Join(before.Tags, ","), strings.Join(after.Tags, ",")},
+	}
+
+	for _, change := range changes {
+		if change.old == change.new {
+			continue
+		}
+		if (change.field == RevisionStart || change.field == RevisionEnd) && sameInstant(change.old, change.new) {
+			continue
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO task_log_revision (log_id, field, old_value, new_value, changed_at, reason)
+			 VALUES (?, ?, ?, ?, ?, ?)`,
+			after.ID,
+			change.field,
+			change.old,
+			change.new,
+			at,
+			reason,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sameInstant(a string, b string) bool {
+	at, errA := time.Parse(time.RFC3339, a)
+	bt, errB := time.Parse(time.RFC3339, b)
+	return errA == nil && errB == nil && at.Equal(bt)
+}
+
+// GetTaskLogRevisions returns the revision history of a log, oldest first.
+func (s *Store) GetTaskLogRevisions(id string) ([]LogRevision, error) {
+	rows, err := s.db.Query(
+		`SELECT id, log_id, field, old_value, new_value, changed_at, reason
+		 FROM task_log_revision
+		 WHERE log_id = ?
+		 ORDER BY julianday(changed_at) ASC, id ASC`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []LogRevision
+	for rows.Next() {
+		var revision LogRevision
+		if err := rows.Scan(
+			&revision.ID,
+			&revision.LogID,
+			&revision.Field,
+			&revision.OldValue,
+			&revision.NewValue,
+			&revision.ChangedAt,
+			&revision.Reason,
+		); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, rows.Err()
+}
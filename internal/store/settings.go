@@ -0,0 +1,146 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	SettingStartExclusive = "start.exclusive"
+	SettingBackupsKeep    = "backups.keep"
+)
+
+type settingSpec struct {
+	defaultValue string
+	description  string
+	validate     func(value string) error
+}
+
+var settingSpecs = map[string]settingSpec{
+	SettingStartExclusive: {
+		defaultValue: "false",
+		description:  "make tt start stop other active tasks, like tt switch",
+		validate:     validateBoolSetting,
+	},
+	SettingBackupsKeep: {
+		defaultValue: "10",
+		description:  "how many automatic snapshots to keep in ~/.tt/backups (0 turns them off)",
+		validate:     validateCountSetting,
+	},
+}
+
+func validateBoolSetting(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("expected true or false")
+	}
+	return nil
+}
+
+func validateCountSetting(value string) error {
+	if n, err := strconv.Atoi(value); err != nil || n < 0 {
+		return fmt.Errorf("expected a whole number of 0 or more")
+	}
+	return nil
+}
+
+func settingSpecFor(key string) (settingSpec, error) {
+	spec, ok := settingSpecs[key]
+	if !ok {
+		return settingSpec{}, fmt.Errorf("%w: %s", ErrUnknownSetting, key)
+	}
+	return spec, nil
+}
+
+// GetSettings lists every known setting with its effective value.
+func (s *Store) GetSettings() ([]Setting, error) {
+	keys := make([]string, 0, len(settingSpecs))
+	for key := range settingSpecs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	settings := make([]Setting, 0, len(keys))
+	for _, key := range keys {
+		setting, err := s.GetSetting(key)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, setting)
+	}
+	return settings, nil
+}
+
+func (s *Store) GetSetting(key string) (Setting, error) {
+	spec, err := settingSpecFor(key)
+	if err != nil {
+		return Setting{}, err
+	}
+
+	setting := Setting{
+		Key:         key,
+		Value:       spec.defaultValue,
+		Default:     spec.defaultValue,
+		Description: spec.description,
+	}
+
+	var value string
+	err = s.db.QueryRow(`SELECT value FROM setting WHERE key = ?`, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return setting, nil
+	}
+	if err != nil {
+		return Setting{}, err
+	}
+
+	setting.Value = value
+	setting.IsSet = true
+	return setting, nil
+}
+
+func (s *Store) SetSetting(key string, value string) error {
+	spec, err := settingSpecFor(key)
+	if err != nil {
+		return err
+	}
+
+	value = strings.TrimSpace(value)
+	if err := spec.validate(value); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	_, err = s.db.Exec(
+		`INSERT INTO setting (key, value) VALUES (?, ?)
+		 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+		key,
+		value,
+	)
+	return err
+}
+
+func (s *Store) UnsetSetting(key string) error {
+	if _, err := settingSpecFor(key); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(`DELETE FROM setting WHERE key = ?`, key)
+	return err
+}
+
+func (s *Store) BoolSetting(key string) (bool, error) {
+	setting, err := s.GetSetting(key)
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(setting.Value)
+}
+
+func (s *Store) IntSetting(key string) (int, error) {
+	setting, err := s.GetSetting(key)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(setting.Value)
+}
@@ -0,0 +1,193 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	snapshotDirName    = "backups"
+	snapshotTimeLayout = "20060102-150405.000"
+)
+
+// Snapshot names look like tt-20260216-093000.123-delete.db.
+var snapshotName = regexp.MustCompile(`^tt-(\d{8}-\d{6}\.\d{3})-([a-z0-9-]+)\.db$`)
+
+func (s *Store) snapshotDir() string {
+	return filepath.Join(s.dir, snapshotDirName)
+}
+
+// Snapshot copies the database into ~/.tt/backups with VACUUM INTO, then
+// prunes old snapshots down to the backups.keep setting. It returns the new
+// snapshot's name, or "" when backups.keep is 0.
+func (s *Store) Snapshot(reason string) (string, error) {
+	keep, err := s.IntSetting(SettingBackupsKeep)
+	if err != nil {
+		return "", err
+	}
+	if keep == 0 {
+		return "", nil
+	}
+
+	if err := os.MkdirAll(s.snapshotDir(), 0755); err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("tt-%s-%s.db", time.Now().Format(snapshotTimeLayout), snapshotReason(reason))
+	if _, err := s.db.Exec(`VACUUM INTO ?`, filepath.Join(s.snapshotDir(), name)); err != nil {
+		return "", err
+	}
+
+	if err := s.pruneSnapshots(keep); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// snapshotBefore takes a snapshot ahead of a destructive operation.
+func (s *Store) snapshotBefore(reason string) error {
+	if _, err := s.Snapshot(reason); err != nil {
+		return fmt.Errorf("could not snapshot database before %s: %w", reason, err)
+	}
+	return nil
+}
+
+func snapshotReason(reason string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(reason) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	slug := strings.Trim(b.String(), "-")
+	if slug == "" {
+		return "manual"
+	}
+	return slug
+}
+
+// ListSnapshots returns the snapshots in ~/.tt/backups, newest first.
+func (s *Store) ListSnapshots() ([]Snapshot, error) {
+	entries, err := os.ReadDir(s.snapshotDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []Snapshot
+	for _, entry := range entries {
+		match := snapshotName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		createdAt, err := time.ParseInLocation(snapshotTimeLayout, match[1], time.Local)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, Snapshot{
+			Name:      entry.Name(),
+			Reason:    match[2],
+			CreatedAt: createdAt,
+			Size:      info.Size(),
+		})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.After(snapshots[j].CreatedAt)
+	})
+	return snapshots, nil
+}
+
+func (s *Store) pruneSnapshots(keep int) error {
+	snapshots, err := s.ListSnapshots()
+	if err != nil {
+		return err
+	}
+	for i := keep; i < len(snapshots); i++ {
+		if err := os.Remove(filepath.Join(s.snapshotDir(), snapshots[i].Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreSnapshot replaces the database with a snapshot. The current
+// database is snapshotted first, so the restore itself can be undone. The
+// store stays usable afterwards, migrated to the current schema.
+func (s *Store) RestoreSnapshot(name string) error {
+	if !snapshotName.MatchString(name) {
+		return fmt.Errorf("%w: %s", ErrSnapshotNotFound, name)
+	}
+	source := filepath.Join(s.snapshotDir(), name)
+	if _, err := os.Stat(source); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", ErrSnapshotNotFound, name)
+		}
+		return err
+	}
+
+	// Copy first: the snapshot taken below may prune the one being restored.
+	target := s.path()
+	staged := target + ".restore"
+	if err := copyFile(source, staged); err != nil {
+		return err
+	}
+	if err := s.snapshotBefore("restore"); err != nil {
+		os.Remove(staged)
+		return err
+	}
+
+	if err := s.db.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(staged, target); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("sqlite3", target)
+	if err != nil {
+		return err
+	}
+	s.db = db
+	_, err = s.Migrate()
+	return err
+}
+
+func copyFile(source string, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(target)
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,199 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SplitTaskLog replaces a log with two logs that meet at at. Both halves get
+// fresh ids and keep the task, project, tags and any pauses on their side.
+func (s *Store) SplitTaskLog(id string, at time.Time) (TaskLogEntry, TaskLogEntry, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return TaskLogEntry{}, TaskLogEntry{}, err
+	}
+
+	entry, err := getTaskLog(tx, id)
+	if err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, TaskLogEntry{}, err
+	}
+	if !at.After(entry.StartTime) || !at.Before(entry.EndTime) {
+		tx.Rollback()
+		return TaskLogEntry{}, TaskLogEntry{}, ErrSplitOutOfRange
+	}
+
+	pauses, err := getLogPauses(tx, id)
+	if err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, TaskLogEntry{}, err
+	}
+
+	base := NewTaskLog{
+		TaskName: entry.TaskName,
+		Project:  ProjectRef{Client: entry.ClientName, Name: entry.ProjectName},
+		Tags:     entry.Tags,
+		Pauses:   pauses,
+	}
+	first := base
+	first.StartTime = entry.StartTime
+	first.EndTime = at
+	second := base
+	second.StartTime = at
+	second.EndTime = entry.EndTime
+
+	j := newJournal(tx, "split log "+id)
+	if err := j.touch(imageLog, id); err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, TaskLogEntry{}, err
+	}
+	if err := deleteTaskLogsTx(tx, []string{id}); err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, TaskLogEntry{}, err
+	}
+	firstEntry, err := insertTaskLogTx(tx, first)
+	if err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, TaskLogEntry{}, err
+	}
+	secondEntry, err := insertTaskLogTx(tx, second)
+	if err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, TaskLogEntry{}, err
+	}
+	j.created(imageLog, firstEntry.ID, secondEntry.ID)
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, TaskLogEntry{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return TaskLogEntry{}, TaskLogEntry{}, err
+	}
+	return firstEntry, secondEntry, nil
+}
+
+// MergeTaskLogs combines sessions of the same task and project into one log
+// with a fresh id. Gaps between the sessions are kept as pauses, so the
+// worked time does not change. The sessions may touch but not overlap, and
+// no other session of the same task may fall between them.
+func (s *Store) MergeTaskLogs(ids []string) (TaskLogEntry, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return TaskLogEntry{}, err
+	}
+
+	var entries []TaskLogEntry
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		entry, err := getTaskLog(tx, id)
+		if err != nil {
+			tx.Rollback()
+			return TaskLogEntry{}, err
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) < 2 {
+		tx.Rollback()
+		return TaskLogEntry{}, ErrMergeTooFew
+	}
+	sortByStart(entries)
+
+	first := entries[0]
+	merged := NewTaskLog{
+		TaskName:  first.TaskName,
+		Project:   ProjectRef{Client: first.ClientName, Name: first.ProjectName},
+		StartTime: first.StartTime,
+		EndTime:   first.EndTime,
+	}
+
+	var tags []string
+	for i, entry := range entries {
+		if entry.TaskName != first.TaskName || entry.ProjectName != first.ProjectName || entry.ClientName != first.ClientName {
+			tx.Rollback()
+			return TaskLogEntry{}, ErrMergeMismatch
+		}
+
+		if i > 0 && entry.StartTime.Before(merged.EndTime) {
+			tx.Rollback()
+			return TaskLogEntry{}, ErrMergeOverlap
+		}
+
+		pauses, err := getLogPauses(tx, entry.ID)
+		if err != nil {
+			tx.Rollback()
+			return TaskLogEntry{}, err
+		}
+		merged.Pauses = append(merged.Pauses, pauses...)
+		if i > 0 && entry.StartTime.After(merged.EndTime) {
+			gapStart := merged.EndTime
+			merged.Pauses = append(merged.Pauses, PauseInterval{StartTime: gapStart, EndTime: &entry.StartTime})
+		}
+		if entry.EndTime.After(merged.EndTime) {
+			merged.EndTime = entry.EndTime
+		}
+		tags = append(tags, entry.Tags...)
+	}
+	merged.Tags, err = NormalizeTags(tags)
+	if err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
+	sort.Slice(merged.Pauses, func(i, j int) bool {
+		return merged.Pauses[i].StartTime.Before(merged.Pauses[j].StartTime)
+	})
+
+	var between int
+	err = tx.QueryRow(
+		`SELECT COUNT(*) FROM task_log
+		 WHERE task_name = ? AND project_id IS (SELECT project_id FROM task_log WHERE id = ?)
+		   AND julianday(start_time) >= julianday(?) AND julianday(start_time) < julianday(?)`,
+		first.TaskName,
+		first.ID,
+		merged.StartTime,
+		merged.EndTime,
+	).Scan(&between)
+	if err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
+	if between != len(entries) {
+		tx.Rollback()
+		return TaskLogEntry{}, ErrMergeNotAdjacent
+	}
+
+	mergedIDs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		mergedIDs = append(mergedIDs, entry.ID)
+	}
+	j := newJournal(tx, fmt.Sprintf("merge %d logs", len(mergedIDs)))
+	if err := j.touch(imageLog, mergedIDs...); err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
+	if err := deleteTaskLogsTx(tx, mergedIDs); err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
+	result, err := insertTaskLogTx(tx, merged)
+	if err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
+	j.created(imageLog, result.ID)
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return TaskLogEntry{}, err
+	}
+	return result, nil
+}
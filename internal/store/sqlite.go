@@ -8,7 +8,25 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 )
 
+const dbFileName = "tt.db"
+
 func Open() (*Store, error) {
+	st, err := OpenUnmigrated()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := st.Migrate(); err != nil {
+		st.db.Close()
+		return nil, err
+	}
+
+	return st, nil
+}
+
+// OpenUnmigrated opens the database without applying pending migrations,
+// so callers can inspect or drive the schema upgrade themselves.
+func OpenUnmigrated() (*Store, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return nil, err
@@ -19,15 +37,18 @@ func Open() (*Store, error) {
 		return nil, err
 	}
 
-	dbPath := filepath.Join(dir, "tt.db")
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := sql.Open("sqlite3", filepath.Join(dir, dbFileName))
 	if err != nil {
 		return nil, err
 	}
 
-	if err := migrate(db); err != nil {
-		return nil, err
-	}
+	return &Store{db: db, dir: dir}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
 
-	return &Store{db: db}, nil
+func (s *Store) path() string {
+	return filepath.Join(s.dir, dbFileName)
 }
@@ -1,11 +1,150 @@
 package store
 
-func (s *Store) StartTask(task string) error {
-	result, err := s.db.Exec(
-		`INSERT INTO active_task (task_name, start_time)
-		 VALUES (?, datetime('now'))
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+)
+
+func (s *Store) StartTask(task string, opts StartOptions) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	j := newJournal(tx, fmt.Sprintf("start %q", task))
+	if err := j.touch(imageActive, task); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := startTaskTx(tx, task, opts); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SwitchTask stops every other active task and starts task in one
+// transaction. The stops happen at opts.StartTime, or now when it is zero,
+// and cannot be in the future. When task is already running it is left as
+// it is and kept reports true; a project or tags in opts must then match
+// the running task. If nothing else is running either, SwitchTask returns
+// ErrTaskAlreadyActive without changing anything.
+func (s *Store) SwitchTask(task string, opts StartOptions) (stopped []TaskLogEntry, kept bool, err error) {
+	if opts.StartTime.IsZero() {
+		opts.StartTime = time.Now()
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	active, err := getActiveTasks(tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, false, err
+	}
+
+	var others []string
+	for _, running := range active {
+		if running.Name != task {
+			others = append(others, running.Name)
+			continue
+		}
+		kept = true
+		if !startOptionsMatch(running, opts) {
+			tx.Rollback()
+			return nil, false, ErrActiveTaskChanged
+		}
+	}
+	if kept && len(others) == 0 {
+		tx.Rollback()
+		return nil, false, ErrTaskAlreadyActive
+	}
+	if len(others) > 0 && opts.StartTime.After(time.Now()) {
+		tx.Rollback()
+		return nil, false, ErrStopInFuture
+	}
+
+	j := newJournal(tx, fmt.Sprintf("switch to %q", task))
+	if err := j.touch(imageActive, append(others, task)...); err != nil {
+		tx.Rollback()
+		return nil, false, err
+	}
+
+	for _, name := range others {
+		entry, err := stopTaskTx(tx, name, opts.StartTime)
+		if err != nil {
+			tx.Rollback()
+			return nil, false, err
+		}
+		j.created(imageLog, entry.ID)
+		stopped = append(stopped, entry)
+	}
+
+	if !kept {
+		if err := startTaskTx(tx, task, opts); err != nil {
+			tx.Rollback()
+			return nil, false, err
+		}
+	}
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return nil, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+	return stopped, kept, nil
+}
+
+// startOptionsMatch reports whether the project and tags in opts, where
+// given, are the ones task already runs with.
+func startOptionsMatch(task ActiveTask, opts StartOptions) bool {
+	if !opts.Project.IsZero() && opts.Project != (ProjectRef{Client: task.ClientName, Name: task.ProjectName}) {
+		return false
+	}
+	if len(opts.Tags) == 0 {
+		return true
+	}
+	tags, err := NormalizeTags(opts.Tags)
+	if err != nil || len(tags) != len(task.Tags) {
+		return false
+	}
+	for _, tag := range tags {
+		if !slices.Contains(task.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func startTaskTx(tx *sql.Tx, task string, opts StartOptions) error {
+	startTime := opts.StartTime
+	if startTime.IsZero() {
+		startTime = time.Now()
+	}
+
+	projectID, err := resolveProjectTx(tx, opts.Project)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(
+		`INSERT INTO active_task (task_name, start_time, project_id)
+		 VALUES (?, ?, ?)
 		 ON CONFLICT(task_name) DO NOTHING`,
 		task,
+		startTime,
+		projectID,
 	)
 	if err != nil {
 		return err
@@ -19,5 +158,23 @@ func (s *Store) StartTask(task string) error {
 		return ErrTaskAlreadyActive
 	}
 
-	return nil
+	return addActiveTagsTx(tx, task, opts.Tags)
+}
+
+func activeTaskNamesTx(tx *sql.Tx) ([]string, error) {
+	rows, err := tx.Query(`SELECT task_name FROM active_task ORDER BY start_time ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
 }
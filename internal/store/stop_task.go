@@ -3,49 +3,107 @@ package store
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 )
 
-func (s *Store) StopTask(task string) (time.Duration, error) {
-	var startTime time.Time
+// StopTask moves an active task into task_log. A zero endTime means now.
+// It returns the log entry that was written.
+func (s *Store) StopTask(task string, endTime time.Time) (TaskLogEntry, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return TaskLogEntry{}, err
+	}
+
+	j := newJournal(tx, fmt.Sprintf("stop %q", task))
+	if err := j.touch(imageActive, task); err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
+	entry, err := stopTaskTx(tx, task, endTime)
+	if err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
+	j.created(imageLog, entry.ID)
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
 
-	err := s.db.QueryRow(
-		`SELECT start_time FROM active_task WHERE task_name = ?`,
+	if err := tx.Commit(); err != nil {
+		return TaskLogEntry{}, err
+	}
+
+	return entry, nil
+}
+
+func stopTaskTx(tx *sql.Tx, task string, endTime time.Time) (TaskLogEntry, error) {
+	var (
+		startTime time.Time
+		projectID sql.NullInt64
+	)
+
+	err := tx.QueryRow(
+		`SELECT start_time, project_id FROM active_task WHERE task_name = ?`,
 		task,
-	).Scan(&startTime)
+	).Scan(&startTime, &projectID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return 0, ErrTaskNotActive
+			return TaskLogEntry{}, ErrTaskNotActive
 		}
-		return 0, err
+		return TaskLogEntry{}, err
 	}
 
-	endTime := time.Now()
-	duration := endTime.Sub(startTime)
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+	if endTime.Before(startTime) {
+		return TaskLogEntry{}, ErrInvalidTimeRange
+	}
 
-	tx, err := s.db.Begin()
+	pauses, err := getActivePauses(tx, task)
 	if err != nil {
-		return 0, err
+		return TaskLogEntry{}, err
 	}
+	if len(pauses) > 0 && endTime.Before(pauses[len(pauses)-1].StartTime) {
+		return TaskLogEntry{}, ErrInvalidTimeRange
+	}
+	paused := pausedWithin(pauses, startTime, endTime)
+	duration := endTime.Sub(startTime) - paused
 
 	logID, err := generateUniqueLogIDTx(tx, "task_log")
 	if err != nil {
-		tx.Rollback()
-		return 0, err
+		return TaskLogEntry{}, err
 	}
 
 	_, err = tx.Exec(
-		`INSERT INTO task_log (id, task_name, start_time, end_time, duration_seconds)
-		 VALUES (?, ?, ?, ?, ?)`,
+		`INSERT INTO task_log (id, task_name, start_time, end_time, duration_seconds, paused_seconds, project_id)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
 		logID,
 		task,
 		startTime,
 		endTime,
 		int(duration.Seconds()),
+		int(paused.Seconds()),
+		projectID,
 	)
 	if err != nil {
-		tx.Rollback()
-		return 0, err
+		return TaskLogEntry{}, err
+	}
+
+	if _, err := replaceLogPausesTx(tx, logID, pauses, startTime, endTime); err != nil {
+		return TaskLogEntry{}, err
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO task_log_tag (log_id, tag_id)
+		 SELECT ?, tag_id FROM active_task_tag WHERE task_name = ?`,
+		logID,
+		task,
+	)
+	if err != nil {
+		return TaskLogEntry{}, err
 	}
 
 	_, err = tx.Exec(
@@ -53,13 +111,24 @@ func (s *Store) StopTask(task string) (time.Duration, error) {
 		task,
 	)
 	if err != nil {
-		tx.Rollback()
-		return 0, err
+		return TaskLogEntry{}, err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return 0, err
+	_, err = tx.Exec(
+		`DELETE FROM active_task_tag WHERE task_name = ?`,
+		task,
+	)
+	if err != nil {
+		return TaskLogEntry{}, err
+	}
+
+	_, err = tx.Exec(
+		`DELETE FROM active_task_pause WHERE task_name = ?`,
+		task,
+	)
+	if err != nil {
+		return TaskLogEntry{}, err
 	}
 
-	return duration, nil
+	return getTaskLog(tx, logID)
 }
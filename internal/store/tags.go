@@ -0,0 +1,180 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TagFilter matches logs by tag. Every group in AnyOf must match at least
+// one tag (groups are ANDed together), and no tag in NoneOf may be present.
+type TagFilter struct {
+	AnyOf  [][]string
+	NoneOf []string
+}
+
+func (f TagFilter) IsZero() bool {
+	return len(f.AnyOf) == 0 && len(f.NoneOf) == 0
+}
+
+// ParseTagFilter reads --tag expressions. Repeated expressions are ANDed,
+// commas inside one expression mean OR, and a leading "!" negates:
+//
+//	--tag billable --tag meeting,oncall --tag '!internal'
+func ParseTagFilter(exprs []string) (TagFilter, error) {
+	var filter TagFilter
+	for _, expr := range exprs {
+		expr = strings.TrimSpace(expr)
+		negate := strings.HasPrefix(expr, "!")
+		expr = strings.TrimPrefix(expr, "!")
+
+		tags, err := NormalizeTags(strings.Split(expr, ","))
+		if err != nil {
+			return TagFilter{}, err
+		}
+		if len(tags) == 0 {
+			return TagFilter{}, fmt.Errorf("--tag cannot be empty")
+		}
+
+		if negate {
+			filter.NoneOf = append(filter.NoneOf, tags...)
+		} else {
+			filter.AnyOf = append(filter.AnyOf, tags)
+		}
+	}
+	return filter, nil
+}
+
+// NormalizeTags trims, lowercases and de-duplicates tag names.
+func NormalizeTags(tags []string) ([]string, error) {
+	seen := map[string]bool{}
+	var out []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if strings.ContainsAny(tag, ",!") {
+			return nil, fmt.Errorf("invalid tag %q. tags cannot contain ',' or '!'", tag)
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		out = append(out, tag)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func splitTags(joined string) []string {
+	if joined == "" {
+		return nil
+	}
+	tags := strings.Split(joined, ",")
+	sort.Strings(tags)
+	return tags
+}
+
+func (f TagFilter) conditions() ([]string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+	for _, group := range f.AnyOf {
+		conditions = append(conditions, `task_log.id IN (`+taggedLogIDsQuery(len(group))+`)`)
+		for _, tag := range group {
+			args = append(args, tag)
+		}
+	}
+	if len(f.NoneOf) > 0 {
+		conditions = append(conditions, `task_log.id NOT IN (`+taggedLogIDsQuery(len(f.NoneOf))+`)`)
+		for _, tag := range f.NoneOf {
+			args = append(args, tag)
+		}
+	}
+	return conditions, args
+}
+
+func taggedLogIDsQuery(count int) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", count), ", ")
+	return `SELECT task_log_tag.log_id FROM task_log_tag
+		JOIN tag ON tag.id = task_log_tag.tag_id
+		WHERE tag.name IN (` + placeholders + `)`
+}
+
+func resolveTagIDsTx(tx *sql.Tx, tags []string) ([]int64, error) {
+	ids := make([]int64, 0, len(tags))
+	for _, tag := range tags {
+		if _, err := tx.Exec(`INSERT INTO tag (name) VALUES (?) ON CONFLICT(name) DO NOTHING`, tag); err != nil {
+			return nil, err
+		}
+		var id int64
+		if err := tx.QueryRow(`SELECT id FROM tag WHERE name = ?`, tag).Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func addActiveTagsTx(tx *sql.Tx, task string, tags []string) error {
+	ids, err := resolveTagIDsTx(tx, tags)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if _, err := tx.Exec(
+			`INSERT INTO active_task_tag (task_name, tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING`,
+			task,
+			id,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addLogTagsTx(tx *sql.Tx, logID string, tags []string) error {
+	ids, err := resolveTagIDsTx(tx, tags)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if _, err := tx.Exec(
+			`INSERT INTO task_log_tag (log_id, tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING`,
+			logID,
+			id,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeLogTagsTx(tx *sql.Tx, logID string, tags []string) error {
+	for _, tag := range tags {
+		if _, err := tx.Exec(
+			`DELETE FROM task_log_tag
+			 WHERE log_id = ? AND tag_id IN (SELECT id FROM tag WHERE name = ?)`,
+			logID,
+			tag,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getLogTagsTx(tx *sql.Tx, logID string) ([]string, error) {
+	var joined string
+	err := tx.QueryRow(
+		`SELECT COALESCE(GROUP_CONCAT(tag.name), '')
+		 FROM task_log_tag
+		 JOIN tag ON tag.id = task_log_tag.tag_id
+		 WHERE task_log_tag.log_id = ?`,
+		logID,
+	).Scan(&joined)
+	return splitTags(joined), err
+}
@@ -79,3 +79,118 @@ func (s *Store) GetActiveTaskNameSuggestions(prefix string, limit int) ([]string
 
 	return suggestions, nil
 }
+
+func (s *Store) GetProjectSuggestions(prefix string, limit int) ([]string, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	prefix = strings.TrimSpace(prefix)
+
+	rows, err := s.db.Query(
+		`SELECT ref
+		 FROM (
+			SELECT CASE WHEN c.name IS NULL THEN p.name ELSE c.name || '/' || p.name END AS ref
+			FROM project p
+			LEFT JOIN client c ON c.id = p.client_id
+		 )
+		 WHERE (? = '' OR LOWER(ref) LIKE LOWER(?) || '%')
+		 ORDER BY ref ASC
+		 LIMIT ?`,
+		prefix,
+		prefix,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := []string{}
+	for rows.Next() {
+		var ref string
+		if err := rows.Scan(&ref); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, ref)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+func (s *Store) GetClientSuggestions(prefix string, limit int) ([]string, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	prefix = strings.TrimSpace(prefix)
+
+	rows, err := s.db.Query(
+		`SELECT name
+		 FROM client
+		 WHERE (? = '' OR LOWER(name) LIKE LOWER(?) || '%')
+		 ORDER BY name ASC
+		 LIMIT ?`,
+		prefix,
+		prefix,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+func (s *Store) GetTagSuggestions(prefix string, limit int) ([]string, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	prefix = strings.TrimSpace(prefix)
+
+	rows, err := s.db.Query(
+		`SELECT name
+		 FROM tag
+		 WHERE (? = '' OR LOWER(name) LIKE LOWER(?) || '%')
+		 ORDER BY name ASC
+		 LIMIT ?`,
+		prefix,
+		prefix,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
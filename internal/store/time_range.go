@@ -0,0 +1,141 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeRange is the half-open interval [From, To). A nil bound leaves that
+// side open, so the zero value covers all time.
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// NewTimeRange returns the range [from, to). A zero time leaves that side
+// open.
+func NewTimeRange(from time.Time, to time.Time) TimeRange {
+	var r TimeRange
+	if !from.IsZero() {
+		r.From = &from
+	}
+	if !to.IsZero() {
+		r.To = &to
+	}
+	return r
+}
+
+func (r TimeRange) IsZero() bool {
+	return r.From == nil && r.To == nil
+}
+
+// Validate rejects a range whose start is not before its end.
+func (r TimeRange) Validate() error {
+	if r.From != nil && r.To != nil && !r.From.Before(*r.To) {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
+
+// Intersect returns the part of r that is also in other.
+func (r TimeRange) Intersect(other TimeRange) TimeRange {
+	out := r
+	if other.From != nil && (out.From == nil || other.From.After(*out.From)) {
+		out.From = other.From
+	}
+	if other.To != nil && (out.To == nil || other.To.Before(*out.To)) {
+		out.To = other.To
+	}
+	return out
+}
+
+// Days splits r into whole calendar days in the location of r.From, the
+// first starting at midnight on r.From's day. An open range has no days.
+func (r TimeRange) Days() []TimeRange {
+	if r.From == nil || r.To == nil {
+		return nil
+	}
+	from := *r.From
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+
+	var days []TimeRange
+	for day.Before(*r.To) {
+		next := day.AddDate(0, 0, 1)
+		days = append(days, NewTimeRange(day, next))
+		day = next
+	}
+	return days
+}
+
+// NamedRanges lists the keywords ParseNamedRange understands besides the
+// YYYY, YYYY-MM, and YYYY-MM-DD forms.
+var NamedRanges = []string{
+	"today",
+	"yesterday",
+	"this-week",
+	"last-week",
+	"this-month",
+	"last-month",
+	"this-quarter",
+	"last-quarter",
+	"this-year",
+	"last-year",
+}
+
+// ParseNamedRange turns a range name into the range it covers in now's
+// location. Weeks start on Monday. Besides NamedRanges it accepts a year
+// (2026), a month (2026-02), or a day (2026-02-16).
+func ParseNamedRange(name string, now time.Time) (TimeRange, error) {
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	week := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+	quarter := time.Date(now.Year(), now.Month()-(now.Month()-1)%3, 1, 0, 0, 0, 0, loc)
+	year := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, loc)
+
+	value := strings.ToLower(strings.TrimSpace(name))
+	switch value {
+	case "today":
+		return NewTimeRange(today, today.AddDate(0, 0, 1)), nil
+	case "yesterday":
+		return NewTimeRange(today.AddDate(0, 0, -1), today), nil
+	case "this-week":
+		return NewTimeRange(week, week.AddDate(0, 0, 7)), nil
+	case "last-week":
+		return NewTimeRange(week.AddDate(0, 0, -7), week), nil
+	case "this-month":
+		return NewTimeRange(month, month.AddDate(0, 1, 0)), nil
+	case "last-month":
+		return NewTimeRange(month.AddDate(0, -1, 0), month), nil
+	case "this-quarter":
+		return NewTimeRange(quarter, quarter.AddDate(0, 3, 0)), nil
+	case "last-quarter":
+		return NewTimeRange(quarter.AddDate(0, -3, 0), quarter), nil
+	case "this-year":
+		return NewTimeRange(year, year.AddDate(1, 0, 0)), nil
+	case "last-year":
+		return NewTimeRange(year.AddDate(-1, 0, 0), year), nil
+	}
+
+	for _, layout := range []struct {
+		format string
+		years  int
+		months int
+		days   int
+	}{
+		{"2006-01-02", 0, 0, 1},
+		{"2006-01", 0, 1, 0},
+		{"2006", 1, 0, 0},
+	} {
+		if start, err := time.ParseInLocation(layout.format, value, loc); err == nil {
+			return NewTimeRange(start, start.AddDate(layout.years, layout.months, layout.days)), nil
+		}
+	}
+
+	return TimeRange{}, fmt.Errorf(
+		"invalid range %q. use %s, YYYY, YYYY-MM, or YYYY-MM-DD",
+		name,
+		strings.Join(NamedRanges, ", "),
+	)
+}
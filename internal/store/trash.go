@@ -0,0 +1,276 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type TrashKind string
+
+const (
+	TrashLog    TrashKind = "log"
+	TrashActive TrashKind = "active"
+)
+
+// trashLogsTx moves logs into the trash and removes them from task_log.
+func trashLogsTx(tx *sql.Tx, j *journal, ids []string, at time.Time) error {
+	if err := j.touch(imageLog, ids...); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		record, err := getLogRecord(tx, id)
+		if err != nil {
+			return err
+		}
+		trashID, err := insertTrashTx(tx, TrashItem{Kind: TrashLog, DeletedAt: at, Log: &record})
+		if err != nil {
+			return err
+		}
+		j.created(imageTrash, trashID)
+	}
+	return deleteTaskLogsTx(tx, ids)
+}
+
+// trashActiveTaskTx moves a running task into the trash.
+func trashActiveTaskTx(tx *sql.Tx, j *journal, task string, at time.Time) error {
+	records, err := getActiveRecords(tx)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if record.Name != task {
+			continue
+		}
+		if err := j.touch(imageActive, task); err != nil {
+			return err
+		}
+		trashID, err := insertTrashTx(tx, TrashItem{Kind: TrashActive, DeletedAt: at, Active: &record})
+		if err != nil {
+			return err
+		}
+		j.created(imageTrash, trashID)
+		return deleteActiveTaskTx(tx, task)
+	}
+	return ErrTaskNotActive
+}
+
+func insertTrashTx(tx *sql.Tx, item TrashItem) (string, error) {
+	if item.ID == "" {
+		id, err := generateUniqueLogIDTx(tx, "trash")
+		if err != nil {
+			return "", err
+		}
+		item.ID = id
+	}
+
+	var (
+		itemID  string
+		payload []byte
+		err     error
+	)
+	switch item.Kind {
+	case TrashLog:
+		itemID = item.Log.ID
+		payload, err = json.Marshal(item.Log)
+	case TrashActive:
+		itemID = item.Active.Name
+		payload, err = json.Marshal(item.Active)
+	default:
+		return "", fmt.Errorf("unknown trash kind %q", item.Kind)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO trash (id, kind, item_id, deleted_at, payload) VALUES (?, ?, ?, ?, ?)`,
+		item.ID,
+		item.Kind,
+		itemID,
+		item.DeletedAt,
+		string(payload),
+	)
+	return item.ID, err
+}
+
+// GetTrash lists trashed items, most recently deleted first.
+func (s *Store) GetTrash() ([]TrashItem, error) {
+	return getTrash(s.db, `ORDER BY julianday(deleted_at) DESC, id ASC`)
+}
+
+func getTrash(q querier, clause string, args ...any) ([]TrashItem, error) {
+	rows, err := q.Query(`SELECT id, kind, deleted_at, payload FROM trash `+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []TrashItem
+	for rows.Next() {
+		var (
+			item    TrashItem
+			payload string
+		)
+		if err := rows.Scan(&item.ID, &item.Kind, &item.DeletedAt, &payload); err != nil {
+			return nil, err
+		}
+		switch item.Kind {
+		case TrashLog:
+			item.Log = &LogRecord{}
+			err = json.Unmarshal([]byte(payload), item.Log)
+		case TrashActive:
+			item.Active = &ActiveRecord{}
+			err = json.Unmarshal([]byte(payload), item.Active)
+		default:
+			err = fmt.Errorf("unknown trash kind %q", item.Kind)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("trash item %s: %w", item.ID, err)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// RestoreTrash puts one trashed item back. ref is a trash id, the original
+// log id, or the name of a trashed active task; when several match, the most
+// recently deleted one wins.
+func (s *Store) RestoreTrash(ref string) (TrashItem, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return TrashItem{}, err
+	}
+
+	items, err := getTrash(tx, `WHERE id = ? OR item_id = ? ORDER BY id = ? DESC, julianday(deleted_at) DESC LIMIT 1`, ref, ref, ref)
+	if err != nil {
+		tx.Rollback()
+		return TrashItem{}, err
+	}
+	if len(items) == 0 {
+		tx.Rollback()
+		return TrashItem{}, ErrTrashNotFound
+	}
+
+	j := newJournal(tx, "restore "+trashItemLabel(items[0])+" from trash")
+	item, err := restoreTrashItemTx(tx, j, items[0])
+	if err != nil {
+		tx.Rollback()
+		return TrashItem{}, err
+	}
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return TrashItem{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return TrashItem{}, err
+	}
+	return item, nil
+}
+
+// RestoreAllTrash puts every trashed item back in one transaction, oldest
+// deletion first.
+func (s *Store) RestoreAllTrash() ([]TrashItem, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := getTrash(tx, `ORDER BY julianday(deleted_at) ASC, id ASC`)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	j := newJournal(tx, fmt.Sprintf("restore %d item(s) from trash", len(items)))
+	restored := make([]TrashItem, 0, len(items))
+	for _, item := range items {
+		back, err := restoreTrashItemTx(tx, j, item)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("trash item %s: %w", item.ID, err)
+		}
+		restored = append(restored, back)
+	}
+
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// restoreTrashItemTx recreates a trashed item and removes it from the trash.
+// A log whose id has since been reused comes back under a fresh id, and one
+// whose import hash has since been re-imported drops the hash.
+func restoreTrashItemTx(tx *sql.Tx, j *journal, item TrashItem) (TrashItem, error) {
+	if err := j.touch(imageTrash, item.ID); err != nil {
+		return TrashItem{}, err
+	}
+
+	switch item.Kind {
+	case TrashLog:
+		entry := item.Log.newTaskLog()
+		if _, err := getTaskLog(tx, entry.ID); err == nil {
+			entry.ID = ""
+		} else if !errors.Is(err, ErrLogNotFound) {
+			return TrashItem{}, err
+		}
+		if entry.ImportHash != "" {
+			exists, err := importHashExistsTx(tx, entry.ImportHash)
+			if err != nil {
+				return TrashItem{}, err
+			}
+			if exists {
+				entry.ImportHash = ""
+			}
+		}
+		added, err := insertTaskLogTx(tx, entry)
+		if err != nil {
+			return TrashItem{}, err
+		}
+		item.Log.ID = added.ID
+		j.created(imageLog, added.ID)
+	case TrashActive:
+		if err := j.touch(imageActive, item.Active.Name); err != nil {
+			return TrashItem{}, err
+		}
+		if err := insertActiveRecordTx(tx, *item.Active); err != nil {
+			return TrashItem{}, err
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM trash WHERE id = ?`, item.ID); err != nil {
+		return TrashItem{}, err
+	}
+	return item, nil
+}
+
+func trashItemLabel(item TrashItem) string {
+	if item.Kind == TrashActive {
+		return fmt.Sprintf("active task %q", item.Active.Name)
+	}
+	return "log " + item.Log.ID
+}
+
+// EmptyTrash permanently removes trashed items. A nil deletedBefore empties
+// the whole trash.
+func (s *Store) EmptyTrash(deletedBefore *time.Time) (int64, error) {
+	query := `DELETE FROM trash`
+	var args []any
+	if deletedBefore != nil {
+		query += ` WHERE julianday(deleted_at) < julianday(?)`
+		args = append(args, *deletedBefore)
+	}
+
+	result, err := s.db.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -6,29 +6,236 @@ import (
 )
 
 type Store struct {
-	db *sql.DB
+	db  *sql.DB
+	dir string
 }
 
 type ActiveTask struct {
-	Name      string
-	StartTime time.Time
+	Name          string     `json:"name"`
+	StartTime     time.Time  `json:"start_time"`
+	ProjectName   string     `json:"project"`
+	ClientName    string     `json:"client"`
+	Tags          []string   `json:"tags"`
+	PausedSeconds int        `json:"paused_seconds"`
+	PausedAt      *time.Time `json:"paused_at"`
+}
+
+type PauseInterval struct {
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
 }
 
 type TaskLogEntry struct {
-	ID              string
-	TaskName        string
-	StartTime       time.Time
-	EndTime         time.Time
-	DurationSeconds int
+	ID              string    `json:"id"`
+	TaskName        string    `json:"task"`
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+	DurationSeconds int       `json:"duration_seconds"`
+	PausedSeconds   int       `json:"paused_seconds"`
+	ProjectName     string    `json:"project"`
+	ClientName      string    `json:"client"`
+	Tags            []string  `json:"tags"`
 }
 
 type TaskDurationSummary struct {
-	TaskName        string
-	DurationSeconds int
+	TaskName        string `json:"task"`
+	ProjectName     string `json:"project"`
+	ClientName      string `json:"client"`
+	Tag             string `json:"tag"`
+	DurationSeconds int    `json:"duration_seconds"`
 }
 
 type TaskLogGroup struct {
-	TaskName        string
-	DurationSeconds int
-	SessionCount    int
+	TaskName        string `json:"task"`
+	ProjectName     string `json:"project"`
+	ClientName      string `json:"client"`
+	Tag             string `json:"tag"`
+	DurationSeconds int    `json:"duration_seconds"`
+	SessionCount    int    `json:"session_count"`
+}
+
+// TimesheetCell is the time one group worked on one day.
+type TimesheetCell struct {
+	Date            string `json:"date"`
+	TaskName        string `json:"task"`
+	ProjectName     string `json:"project"`
+	ClientName      string `json:"client"`
+	Tag             string `json:"tag"`
+	DurationSeconds int    `json:"duration_seconds"`
+}
+
+// DailySession is the part of a session that falls on one day. A session
+// that crosses midnight appears once for each day.
+type DailySession struct {
+	Date            string    `json:"date"`
+	ID              string    `json:"id"`
+	TaskName        string    `json:"task"`
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+	DurationSeconds int       `json:"duration_seconds"`
+	ProjectName     string    `json:"project"`
+	ClientName      string    `json:"client"`
+	Tags            []string  `json:"tags"`
+}
+
+type Project struct {
+	Name            string `json:"name"`
+	ClientName      string `json:"client"`
+	SessionCount    int    `json:"session_count"`
+	DurationSeconds int    `json:"duration_seconds"`
+}
+
+type NewTaskLog struct {
+	// ID keeps an existing log id, as when restoring a backup. Empty means
+	// generate a fresh one.
+	ID        string
+	TaskName  string
+	StartTime time.Time
+	EndTime   time.Time
+	Project   ProjectRef
+	Tags      []string
+	Pauses    []PauseInterval
+	// ImportHash identifies a session brought in by tt import, so the same
+	// source row is never inserted twice.
+	ImportHash string
+}
+
+type StartOptions struct {
+	Project   ProjectRef
+	Tags      []string
+	StartTime time.Time
+}
+
+type TaskLogUpdate struct {
+	TaskName   *string
+	StartTime  *time.Time
+	EndTime    *time.Time
+	Project    *ProjectRef
+	AddTags    []string
+	RemoveTags []string
+	Reason     string
+}
+
+type LogRevision struct {
+	ID        int64     `json:"id"`
+	LogID     string    `json:"log_id"`
+	Field     string    `json:"field"`
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	ChangedAt time.Time `json:"changed_at"`
+	Reason    string    `json:"reason"`
+}
+
+type LogIssue struct {
+	Kind    LogIssueKind
+	Log     TaskLogEntry
+	Other   TaskLogEntry
+	Overlap time.Duration
+}
+
+type ImportResult struct {
+	Added   []TaskLogEntry
+	Skipped []NewTaskLog
+	// Conflicts lists overlaps between added sessions (Other) and sessions
+	// that were already logged (Log). They are reported, not resolved.
+	Conflicts []LogIssue
+}
+
+// LogRecord is a complete image of one task_log row and everything attached
+// to it, as written to backups.
+type LogRecord struct {
+	ID         string          `json:"id"`
+	TaskName   string          `json:"task"`
+	StartTime  time.Time       `json:"start_time"`
+	EndTime    time.Time       `json:"end_time"`
+	Project    string          `json:"project"`
+	Client     string          `json:"client"`
+	Tags       []string        `json:"tags"`
+	Pauses     []PauseInterval `json:"pauses"`
+	ImportHash string          `json:"import_hash,omitempty"`
+}
+
+// ActiveRecord is a complete image of one running task.
+type ActiveRecord struct {
+	Name      string          `json:"name"`
+	StartTime time.Time       `json:"start_time"`
+	Project   string          `json:"project"`
+	Client    string          `json:"client"`
+	Tags      []string        `json:"tags"`
+	Pauses    []PauseInterval `json:"pauses"`
+}
+
+type ProjectRecord struct {
+	Name   string `json:"name"`
+	Client string `json:"client"`
+}
+
+type SettingRecord struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Backup is the versioned JSON document written by tt backup.
+type Backup struct {
+	Format        string          `json:"format"`
+	Version       int             `json:"version"`
+	SchemaVersion int             `json:"schema_version"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Projects      []ProjectRecord `json:"projects"`
+	Tags          []string        `json:"tags"`
+	Settings      []SettingRecord `json:"settings"`
+	ActiveTasks   []ActiveRecord  `json:"active_tasks"`
+	Logs          []LogRecord     `json:"logs"`
+	Trash         []TrashItem     `json:"trash"`
+	Revisions     []LogRevision   `json:"revisions"`
+}
+
+// TrashItem is a deleted log or active task. Exactly one of Log and Active
+// is set, depending on Kind.
+type TrashItem struct {
+	ID        string        `json:"id"`
+	Kind      TrashKind     `json:"kind"`
+	DeletedAt time.Time     `json:"deleted_at"`
+	Log       *LogRecord    `json:"log,omitempty"`
+	Active    *ActiveRecord `json:"active,omitempty"`
+}
+
+type RestoreResult struct {
+	Logs          int
+	ActiveTasks   int
+	Settings      int
+	Trash         int
+	Revisions     int
+	SkippedLogs   int
+	SkippedActive int
+}
+
+type Operation struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	UndoneAt  *time.Time `json:"undone_at"`
+	Changes   int        `json:"changes"`
+}
+
+type Snapshot struct {
+	Name      string    `json:"name"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size_bytes"`
+}
+
+type Setting struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Default     string `json:"default"`
+	Description string `json:"description"`
+	IsSet       bool   `json:"is_set"`
+}
+
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+	Unknown   bool
 }
@@ -2,34 +2,28 @@ package store
 
 import (
 	"database/sql"
-	"errors"
 	"time"
 )
 
-func (s *Store) UpdateTaskLog(
-	id string,
-	taskName *string,
-	startTime *time.Time,
-	endTime *time.Time,
-) (TaskLogEntry, error) {
-	var entry TaskLogEntry
+func (s *Store) UpdateTaskLog(id string, update TaskLogUpdate) (TaskLogEntry, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return TaskLogEntry{}, err
+	}
 
-	err := s.db.QueryRow(
-		`SELECT id, task_name, start_time, end_time, duration_seconds
-		 FROM task_log
-		 WHERE id = ?`,
-		id,
-	).Scan(
-		&entry.ID,
-		&entry.TaskName,
-		&entry.StartTime,
-		&entry.EndTime,
-		&entry.DurationSeconds,
-	)
+	entry, err := getTaskLog(tx, id)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return TaskLogEntry{}, ErrLogNotFound
-		}
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
+	j := newJournal(tx, "update log "+id)
+	if err := j.touch(imageLog, id); err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
+	before, err := getLogRecord(tx, id)
+	if err != nil {
+		tx.Rollback()
 		return TaskLogEntry{}, err
 	}
 
@@ -37,33 +31,75 @@ func (s *Store) UpdateTaskLog(
 	updatedStart := entry.StartTime
 	updatedEnd := entry.EndTime
 
-	if taskName != nil {
-		updatedName = *taskName
+	if update.TaskName != nil {
+		updatedName = *update.TaskName
 	}
-	if startTime != nil {
-		updatedStart = *startTime
+	if update.StartTime != nil {
+		updatedStart = *update.StartTime
 	}
-	if endTime != nil {
-		updatedEnd = *endTime
+	if update.EndTime != nil {
+		updatedEnd = *update.EndTime
 	}
 
-	if updatedEnd.Before(updatedStart) {
-		return TaskLogEntry{}, ErrInvalidTimeRange
+	if err := validateTaskLog(updatedName, updatedStart, updatedEnd); err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
 	}
 
-	durationSeconds := int(updatedEnd.Sub(updatedStart).Seconds())
+	if _, err := tx.Exec(`UPDATE task_log SET task_name = ? WHERE id = ?`, updatedName, id); err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
+	durationSeconds, pausedSeconds, err := setLogTimesTx(tx, id, updatedStart, updatedEnd)
+	if err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
 
-	_, err = s.db.Exec(
-		`UPDATE task_log
-		 SET task_name = ?, start_time = ?, end_time = ?, duration_seconds = ?
-		 WHERE id = ?`,
-		updatedName,
-		updatedStart,
-		updatedEnd,
-		durationSeconds,
-		id,
-	)
+	if update.Project != nil {
+		projectID, err := resolveProjectTx(tx, *update.Project)
+		if err != nil {
+			tx.Rollback()
+			return TaskLogEntry{}, err
+		}
+		if _, err := tx.Exec(`UPDATE task_log SET project_id = ? WHERE id = ?`, projectID, id); err != nil {
+			tx.Rollback()
+			return TaskLogEntry{}, err
+		}
+		entry.ProjectName = update.Project.Name
+		entry.ClientName = update.Project.Client
+	}
+
+	if len(update.AddTags) > 0 || len(update.RemoveTags) > 0 {
+		if err := addLogTagsTx(tx, id, update.AddTags); err != nil {
+			tx.Rollback()
+			return TaskLogEntry{}, err
+		}
+		if err := removeLogTagsTx(tx, id, update.RemoveTags); err != nil {
+			tx.Rollback()
+			return TaskLogEntry{}, err
+		}
+		entry.Tags, err = getLogTagsTx(tx, id)
+		if err != nil {
+			tx.Rollback()
+			return TaskLogEntry{}, err
+		}
+	}
+
+	after, err := getLogRecord(tx, id)
 	if err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
+	if err := recordRevisionsTx(tx, before, after, time.Now(), update.Reason); err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
+	if err := j.record(); err != nil {
+		tx.Rollback()
+		return TaskLogEntry{}, err
+	}
+	if err := tx.Commit(); err != nil {
 		return TaskLogEntry{}, err
 	}
 
@@ -71,5 +107,36 @@ func (s *Store) UpdateTaskLog(
 	entry.StartTime = updatedStart
 	entry.EndTime = updatedEnd
 	entry.DurationSeconds = durationSeconds
+	entry.PausedSeconds = pausedSeconds
 	return entry, nil
 }
+
+// setLogTimesTx moves a log to [start, end], clipping its pauses to the new
+// range and recomputing the worked and paused seconds.
+func setLogTimesTx(tx *sql.Tx, id string, start time.Time, end time.Time) (int, int, error) {
+	pauses, err := getLogPauses(tx, id)
+	if err != nil {
+		return 0, 0, err
+	}
+	paused, err := replaceLogPausesTx(tx, id, pauses, start, end)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	durationSeconds := int((end.Sub(start) - paused).Seconds())
+	pausedSeconds := int(paused.Seconds())
+	_, err = tx.Exec(
+		`UPDATE task_log
+		 SET start_time = ?, end_time = ?, duration_seconds = ?, paused_seconds = ?
+		 WHERE id = ?`,
+		start,
+		end,
+		durationSeconds,
+		pausedSeconds,
+		id,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+	return durationSeconds, pausedSeconds, nil
+}
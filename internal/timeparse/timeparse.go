@@ -0,0 +1,351 @@
+// Package timeparse reads the dates, times, and durations people type on the
+// command line: "yesterday 3pm", "last monday 09:00", "-45m", "2h ago",
+// "noon", "2026-W07-1", as well as plain layouts like "2026-02-16 09:15".
+// Everything relative is resolved against an injectable clock.
+package timeparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parser resolves relative input against Now. The zero value uses the
+// system clock and places clock-only input on today.
+type Parser struct {
+	// Now returns the current time. Nil means time.Now.
+	Now func() time.Time
+	// Day is where input without a date, such as "15:04" or "noon", lands.
+	// The zero value means the day of Now.
+	Day time.Time
+}
+
+// Error reports input that could not be parsed, along with the part that
+// was understood, if any.
+type Error struct {
+	Input      string
+	Understood string
+	Rest       string
+}
+
+const usage = `use a date (2026-02-16, 2026-W07-1, yesterday, last monday), a time ` +
+	`(15:04, 3pm, 3:30 PM, noon), both ("yesterday 3pm"), or an offset (-45m, 2h ago, in 10m)`
+
+func (e *Error) Error() string {
+	if e.Understood == "" {
+		return fmt.Sprintf("could not understand %q. %s", e.Input, usage)
+	}
+	return fmt.Sprintf(
+		"could not understand %q: read %s, but not %q. %s",
+		e.Input,
+		e.Understood,
+		e.Rest,
+		usage,
+	)
+}
+
+var layouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02 3:04 PM",
+	"2006-01-02 3:04PM",
+}
+
+// Parse returns the time described by input.
+func (p Parser) Parse(input string) (time.Time, error) {
+	now := p.now()
+	raw := strings.TrimSpace(input)
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, raw, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	text := normalize(raw)
+	switch {
+	case text == "":
+		return time.Time{}, &Error{Input: input}
+	case text == "now":
+		return now, nil
+	}
+	if offset, ok := parseOffset(text); ok {
+		return now.Add(offset), nil
+	}
+
+	tokens := strings.Fields(text)
+	day, used, ok := parseDay(tokens, now)
+	if !ok {
+		// Also accept the time first, as in "3pm yesterday".
+		if len(tokens) > 1 {
+			if clock, ok := parseClock(tokens[0]); ok {
+				if day, used, ok := parseDay(tokens[1:], now); ok && used == len(tokens)-1 {
+					return clock.on(day), nil
+				}
+			}
+		}
+		clock, ok := parseClock(text)
+		if !ok {
+			return time.Time{}, &Error{Input: input}
+		}
+		return clock.on(p.day(now)), nil
+	}
+
+	rest := tokens[used:]
+	if len(rest) > 0 && rest[0] == "at" {
+		rest = rest[1:]
+	}
+	if len(rest) == 0 {
+		return day, nil
+	}
+	clock, ok := parseClock(strings.Join(rest, " "))
+	if !ok {
+		return time.Time{}, &Error{
+			Input:      input,
+			Understood: fmt.Sprintf("%q as %s", strings.Join(tokens[:used], " "), day.Format("Mon Jan 2, 2006")),
+			Rest:       strings.Join(rest, " "),
+		}
+	}
+	return clock.on(day), nil
+}
+
+func (p Parser) now() time.Time {
+	if p.Now == nil {
+		return time.Now()
+	}
+	return p.Now()
+}
+
+func (p Parser) day(now time.Time) time.Time {
+	day := p.Day
+	if day.IsZero() {
+		day = now
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+}
+
+var (
+	spacedMeridiem = regexp.MustCompile(`(\d)\s+([ap])\.?m\.?\b`)
+	dottedMeridiem = regexp.MustCompile(`([ap])\.m\.`)
+)
+
+// normalize lowercases input and joins "3 pm" and "3 p.m." into "3pm".
+func normalize(input string) string {
+	text := strings.ToLower(strings.TrimSpace(input))
+	text = dottedMeridiem.ReplaceAllString(text, "${1}m")
+	text = spacedMeridiem.ReplaceAllString(text, "${1}${2}m")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// parseOffset reads "-45m", "+1h30m", "2h ago", "2 hours ago", and "in 10m".
+func parseOffset(text string) (time.Duration, bool) {
+	switch {
+	case strings.HasPrefix(text, "-"), strings.HasPrefix(text, "+"):
+		d, err := ParseDuration(text[1:])
+		if err != nil {
+			return 0, false
+		}
+		if text[0] == '-' {
+			d = -d
+		}
+		return d, true
+	case strings.HasSuffix(text, " ago"):
+		d, err := ParseDuration(strings.TrimSuffix(text, " ago"))
+		return -d, err == nil
+	case strings.HasPrefix(text, "in "):
+		d, err := ParseDuration(strings.TrimPrefix(text, "in "))
+		return d, err == nil
+	}
+	return 0, false
+}
+
+var isoWeek = regexp.MustCompile(`^(\d{4})-w(\d{2})(?:-([1-7]))?$`)
+
+// parseDay reads a day from the start of tokens and returns its midnight
+// and the number of tokens used.
+func parseDay(tokens []string, now time.Time) (time.Time, int, bool) {
+	if len(tokens) == 0 {
+		return time.Time{}, 0, false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch tokens[0] {
+	case "today":
+		return today, 1, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), 1, true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), 1, true
+	case "last", "this", "next":
+		if len(tokens) < 2 {
+			return time.Time{}, 0, false
+		}
+		weekday, ok := parseWeekday(tokens[1])
+		if !ok {
+			return time.Time{}, 0, false
+		}
+		return relativeWeekday(today, weekday, tokens[0]), 2, true
+	}
+
+	if weekday, ok := parseWeekday(tokens[0]); ok {
+		return relativeWeekday(today, weekday, "this"), 1, true
+	}
+	if day, err := time.ParseInLocation("2006-01-02", tokens[0], now.Location()); err == nil {
+		return day, 1, true
+	}
+	if match := isoWeek.FindStringSubmatch(tokens[0]); match != nil {
+		day, ok := isoWeekDay(match, now.Location())
+		return day, 1, ok
+	}
+	return time.Time{}, 0, false
+}
+
+// relativeWeekday finds weekday around today. "this" is the most recent one
+// on or before today, "last" the most recent one before today, and "next"
+// the first one after today.
+func relativeWeekday(today time.Time, weekday time.Weekday, which string) time.Time {
+	back := (int(today.Weekday()) - int(weekday) + 7) % 7
+	switch which {
+	case "last":
+		if back == 0 {
+			back = 7
+		}
+		return today.AddDate(0, 0, -back)
+	case "next":
+		ahead := (int(weekday) - int(today.Weekday()) + 7) % 7
+		if ahead == 0 {
+			ahead = 7
+		}
+		return today.AddDate(0, 0, ahead)
+	default:
+		return today.AddDate(0, 0, -back)
+	}
+}
+
+func parseWeekday(token string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if token == name || token == name[:3] {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// isoWeekDay resolves 2026-W07 (Monday) or 2026-W07-3 (Wednesday).
+func isoWeekDay(match []string, loc *time.Location) (time.Time, bool) {
+	year, _ := strconv.Atoi(match[1])
+	week, _ := strconv.Atoi(match[2])
+	weekday := 1
+	if match[3] != "" {
+		weekday, _ = strconv.Atoi(match[3])
+	}
+
+	// January 4th is always in week 1.
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	day := monday.AddDate(0, 0, (week-1)*7+weekday-1)
+	if _, w := day.ISOWeek(); w != week || week < 1 {
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+// clock is a wall-clock time of day.
+type clock struct {
+	hour, minute, second int
+}
+
+// on returns the clock time on day. It sets the wall clock rather than
+// adding to midnight, so days with a DST change come out right.
+func (c clock) on(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), c.hour, c.minute, c.second, 0, day.Location())
+}
+
+var clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(?::(\d{2}))?(am|pm)?$`)
+
+// parseClock reads a time of day.
+func parseClock(text string) (clock, bool) {
+	switch text {
+	case "noon", "midday":
+		return clock{hour: 12}, true
+	case "midnight":
+		return clock{}, true
+	}
+
+	match := clockPattern.FindStringSubmatch(text)
+	if match == nil || (match[2] == "" && match[4] == "") {
+		return clock{}, false
+	}
+	hour, _ := strconv.Atoi(match[1])
+	minute, _ := strconv.Atoi(match[2])
+	second, _ := strconv.Atoi(match[3])
+	if minute > 59 || second > 59 {
+		return clock{}, false
+	}
+	switch match[4] {
+	case "":
+		if hour > 23 {
+			return clock{}, false
+		}
+	default:
+		if hour < 1 || hour > 12 {
+			return clock{}, false
+		}
+		hour %= 12
+		if match[4] == "pm" {
+			hour += 12
+		}
+	}
+	return clock{hour: hour, minute: minute, second: second}, true
+}
+
+var (
+	durationPart = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*([a-z]+)`)
+	durationUnit = map[string]time.Duration{
+		"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+		"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+		"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+		"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+	}
+)
+
+// ParseDuration reads a non-negative duration: Go syntax like 1h30m, or
+// amounts with units such as "90 min", "1.5h", "2 hours 15 minutes", "3d",
+// or "2w". Days are 24 hours.
+func ParseDuration(input string) (time.Duration, error) {
+	text := strings.ToLower(strings.TrimSpace(input))
+	if d, err := time.ParseDuration(text); err == nil && d >= 0 {
+		return d, nil
+	}
+
+	matches := durationPart.FindAllStringSubmatchIndex(text, -1)
+	var (
+		total time.Duration
+		end   int
+	)
+	for _, m := range matches {
+		if strings.TrimSpace(text[end:m[0]]) != "" {
+			return 0, durationError(input)
+		}
+		amount, err := strconv.ParseFloat(text[m[2]:m[3]], 64)
+		unit, ok := durationUnit[text[m[4]:m[5]]]
+		if err != nil || !ok {
+			return 0, durationError(input)
+		}
+		total += time.Duration(amount * float64(unit))
+		end = m[1]
+	}
+	if len(matches) == 0 || strings.TrimSpace(text[end:]) != "" {
+		return 0, durationError(input)
+	}
+	return total, nil
+}
+
+func durationError(input string) error {
+	return fmt.Errorf("could not understand duration %q. use e.g. 45m, 1h30m, 1.5h, \"90 min\", 3d, or 2w", input)
+}